@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,10 +17,26 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+const (
+	transportStdio = "stdio"
+	transportHTTP  = "http"
+	transportSSE   = "sse"
+)
+
 func main() {
 	configPath := flag.String("c", "", "path to config file")
+	transport := flag.String("transport", transportHTTP, "transport to serve MCP over: stdio, http or sse")
 	flag.Parse()
 
+	// stdout is reserved for the protocol stream in stdio mode, keep all logs on stderr.
+	log.SetOutput(os.Stderr)
+
+	switch *transport {
+	case transportStdio, transportHTTP, transportSSE:
+	default:
+		log.Fatalf("Unknown transport %q, must be one of stdio, http or sse", *transport)
+	}
+
 	var conf *config.Config
 	var err error
 
@@ -38,28 +55,140 @@ func main() {
 	server := mcp.NewServer(&mcp.Implementation{
 		Name: "metadata-mcp-server",
 	}, nil)
+	server.AddReceivingMiddleware(mcptools.ToolDeadlineMiddleware(conf.ToolTimeout, conf.ToolTimeouts))
+
+	cacheStore := newCacheStore(conf.Cache)
 
 	// ------ Add Tools BEGIN ------
-	mcptools.NewTMDB(conf.TMDBAPIKey, conf.TMDBResponseLanguage).AddTools(server)
-	mcptools.NewThePornDB(conf.ThePornDBAPIToken).AddTools(server)
-	mcptools.NewMetatube(conf.MetaTubeAPIURL, conf.MetaTubeAPIKEY).AddTools(server)
-	ddg, err := mcptools.NewDuckDuckGo()
-	if err != nil {
-		log.Fatalf("Error creating DuckDuckGo tool: %v", err)
+	var providers []string
+	if *conf.TMDBEnabled {
+		mcptools.NewTMDB(conf.TMDBAPIKey, conf.TMDBResponseLanguage, newHTTPClient("tmdb", conf.TMDBHTTP),
+			newResponseCache(cacheStore, conf.Cache.TMDBTTL)).AddTools(server)
+		providers = append(providers, "tmdb")
+	}
+	if *conf.ThePornDBEnabled {
+		mcptools.NewThePornDB(conf.ThePornDBAPIToken, conf.MediaRoot, newHTTPClient("theporndb", conf.ThePornDBHTTP),
+			newResponseCache(cacheStore, conf.Cache.ThePornDBTTL)).AddTools(server)
+		providers = append(providers, "theporndb")
 	}
-	ddg.AddTools(server)
-	mcptools.NewFetcher().AddTools(server)
-	mcptools.NewWikipedia(conf.WikipediaLanguage).AddTools(server)
+	if *conf.MetaTubeEnabled {
+		mcptools.NewMetatube(conf.MetaTubeAPIURL, conf.MetaTubeAPIKEY, newHTTPClient("metatube", conf.MetaTubeHTTP),
+			newResponseCache(cacheStore, conf.Cache.MetaTubeTTL)).AddTools(server)
+		providers = append(providers, "metatube")
+	}
+	if len(conf.WebSearchBackends) > 0 {
+		var backends []mcptools.SearchBackend
+		for _, name := range conf.WebSearchBackends {
+			switch name {
+			case "duckduckgo":
+				backends = append(backends, mcptools.NewDuckDuckGo(newHTTPClient("duckduckgo", conf.DuckDuckGoHTTP)))
+			case "searxng":
+				backends = append(backends, mcptools.NewSearXNG(conf.SearXNGURL, newHTTPClient("searxng", conf.SearXNGHTTP)))
+			case "brave":
+				backends = append(backends, mcptools.NewBrave(conf.BraveAPIKey, newHTTPClient("brave", conf.BraveHTTP)))
+			}
+		}
+		mcptools.NewWebSearch(backends...).AddTools(server)
+		providers = append(providers, conf.WebSearchBackends...)
+	}
+	if *conf.FetchEnabled {
+		mcptools.NewFetcher(newFetchHTTPClient(conf), mcptools.FetcherOptions{
+			MaxDownloadSize:      conf.Fetch.MaxDownloadSize,
+			AllowedDomains:       conf.Fetch.AllowedDomains,
+			DeniedDomains:        conf.Fetch.DeniedDomains,
+			AllowPrivateNetworks: conf.Fetch.AllowPrivateNetworks,
+		}).AddTools(server)
+		providers = append(providers, "fetch")
+	}
+	if *conf.WikipediaEnabled {
+		mcptools.NewWikipedia(conf.WikipediaLanguage, newHTTPClient("wikipedia", conf.WikipediaHTTP)).AddTools(server)
+		providers = append(providers, "wikipedia")
+	}
+	if len(providers) == 0 {
+		log.Fatalf("No provider is enabled, nothing to serve")
+	}
+	log.Printf("Enabled providers: %s", strings.Join(providers, ", "))
 	// ------ Add Tools END ------
 
-	// Create HTTP handler
+	switch *transport {
+	case transportStdio:
+		serveStdio(server)
+	case transportSSE:
+		serveHTTP(conf.Port, mcp.NewSSEHandler(func(req *http.Request) *mcp.Server {
+			return server
+		}, nil))
+	default:
+		serveHTTP(conf.Port, mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
+			return server
+		}, nil))
+	}
+}
 
-	handler := mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
-		return server
-	}, nil)
+func newHTTPClient(provider string, conf config.HTTPConfig) *http.Client {
+	client, err := mcptools.NewHTTPClient(mcptools.HTTPClientOptions{
+		Timeout:    conf.Timeout,
+		MaxRetries: conf.MaxRetries,
+		Proxy:      conf.Proxy,
+	})
+	if err != nil {
+		log.Fatalf("Error creating HTTP client for %s: %v", provider, err)
+	}
+	return client
+}
+
+// newFetchHTTPClient also refuses connections to private networks unless they are allowed, fetch
+// requests urls chosen by the model.
+func newFetchHTTPClient(conf *config.Config) *http.Client {
+	client, err := mcptools.NewHTTPClient(mcptools.HTTPClientOptions{
+		Timeout:              conf.FetchHTTP.Timeout,
+		MaxRetries:           conf.FetchHTTP.MaxRetries,
+		Proxy:                conf.FetchHTTP.Proxy,
+		BlockPrivateNetworks: !conf.Fetch.AllowPrivateNetworks,
+	})
+	if err != nil {
+		log.Fatalf("Error creating HTTP client for fetch: %v", err)
+	}
+	return client
+}
+
+// newCacheStore returns nil when the cache is disabled.
+func newCacheStore(conf config.CacheConfig) mcptools.CacheStore {
+	if conf.MaxEntries < 0 {
+		return nil
+	}
+	memory := mcptools.NewMemoryCache(conf.MaxEntries)
+	if conf.Dir == "" {
+		return memory
+	}
+	disk, err := mcptools.NewDiskCache(conf.Dir, conf.DirMaxEntries)
+	if err != nil {
+		log.Fatalf("Error creating disk cache in %s: %v", conf.Dir, err)
+	}
+	return mcptools.NewTieredCache(memory, disk)
+}
+
+func newResponseCache(store mcptools.CacheStore, ttl time.Duration) *mcptools.ResponseCache {
+	if store == nil || ttl < 0 {
+		return nil
+	}
+	return mcptools.NewResponseCache(store, ttl)
+}
+
+func serveStdio(server *mcp.Server) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Server starting on stdio")
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && ctx.Err() == nil {
+		log.Fatalf("Server failed: %v", err)
+	}
+
+	log.Println("Server stopped")
+}
 
+func serveHTTP(port int, handler http.Handler) {
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%v", conf.Port),
+		Addr:    fmt.Sprintf(":%v", port),
 		Handler: handler,
 	}
 
@@ -68,7 +197,7 @@ func main() {
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		log.Printf("Server starting on port %v", conf.Port)
+		log.Printf("Server starting on port %v", port)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed: %v", err)
 		}
@@ -3,10 +3,50 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.yaml.in/yaml/v4"
 )
 
+// HTTPConfig tunes the outbound HTTP client of a provider.
+type HTTPConfig struct {
+	// Timeout of a whole request including retries, e.g. "30s". Default is 30s.
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxRetries on 429 and 5xx responses. Default is 2, negative disables retry.
+	MaxRetries int `yaml:"max_retries"`
+	// Proxy is an http, https or socks5 URL, e.g. "socks5://127.0.0.1:1080".
+	Proxy string `yaml:"proxy"`
+}
+
+// CacheConfig configures the response cache of provider lookups.
+type CacheConfig struct {
+	// MaxEntries of the in-memory LRU. Default is 1000, negative disables the cache.
+	MaxEntries int `yaml:"max_entries"`
+	// Dir enables an on-disk store behind the in-memory LRU, which survives restarts.
+	Dir string `yaml:"dir"`
+	// DirMaxEntries bounds the on-disk store, evicting the oldest entries beyond it. Default is
+	// 10000, negative leaves it unbounded. Expired entries are swept on start either way.
+	DirMaxEntries int `yaml:"dir_max_entries"`
+	// Per-provider TTLs, e.g. "12h". Default is 24h, negative disables caching for the provider.
+	TMDBTTL      time.Duration `yaml:"tmdb_ttl"`
+	ThePornDBTTL time.Duration `yaml:"theporndb_ttl"`
+	MetaTubeTTL  time.Duration `yaml:"metatube_ttl"`
+}
+
+// FetchConfig configures the fetch tool.
+type FetchConfig struct {
+	// MaxDownloadSize in bytes, the rest of a larger response is dropped. Default is 10 MiB.
+	MaxDownloadSize int64 `yaml:"max_download_size"`
+	// AllowedDomains, when set, are the only domains (and their subdomains) fetch may request.
+	AllowedDomains []string `yaml:"allowed_domains"`
+	// DeniedDomains (and their subdomains) are never requested.
+	DeniedDomains []string `yaml:"denied_domains"`
+	// AllowPrivateNetworks lets fetch reach loopback, private and link-local addresses.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
 type Config struct {
 	Port                 int    `yaml:"port"`
 	TMDBAPIKey           string `yaml:"tmdb_api_key"`
@@ -15,6 +55,53 @@ type Config struct {
 	MetaTubeAPIURL       string `yaml:"metatube_api_url"`
 	MetaTubeAPIKEY       string `yaml:"metatube_api_key"`
 	WikipediaLanguage    string `yaml:"wikipedia_language"`
+	SearXNGURL           string `yaml:"searxng_url"`
+	BraveAPIKey          string `yaml:"brave_api_key"`
+	// MediaRoot is the directory find_porn_by_hash may read video files from to hash them.
+	MediaRoot string `yaml:"media_root"`
+
+	// WebSearchBackends are tried in order by web_search, falling back to the next one on failure.
+	// One of duckduckgo, searxng or brave. Default is every configured backend, keyed ones first.
+	WebSearchBackends []string `yaml:"web_search_backends"`
+
+	// Providers are enabled when their credentials are present, set these to force them on or off.
+	// After validation all of them are non-nil.
+	TMDBEnabled       *bool `yaml:"tmdb_enabled"`
+	ThePornDBEnabled  *bool `yaml:"theporndb_enabled"`
+	MetaTubeEnabled   *bool `yaml:"metatube_enabled"`
+	DuckDuckGoEnabled *bool `yaml:"duckduckgo_enabled"`
+	FetchEnabled      *bool `yaml:"fetch_enabled"`
+	WikipediaEnabled  *bool `yaml:"wikipedia_enabled"`
+
+	TMDBHTTP       HTTPConfig `yaml:"tmdb_http"`
+	ThePornDBHTTP  HTTPConfig `yaml:"theporndb_http"`
+	MetaTubeHTTP   HTTPConfig `yaml:"metatube_http"`
+	DuckDuckGoHTTP HTTPConfig `yaml:"duckduckgo_http"`
+	FetchHTTP      HTTPConfig `yaml:"fetch_http"`
+	WikipediaHTTP  HTTPConfig `yaml:"wikipedia_http"`
+	SearXNGHTTP    HTTPConfig `yaml:"searxng_http"`
+	BraveHTTP      HTTPConfig `yaml:"brave_http"`
+
+	Cache CacheConfig `yaml:"cache"`
+	Fetch FetchConfig `yaml:"fetch"`
+
+	// ToolTimeout is the deadline of a tool call, e.g. "2m". Default is 2m, negative disables it.
+	ToolTimeout time.Duration `yaml:"tool_timeout"`
+	// ToolTimeouts overrides ToolTimeout per tool name.
+	ToolTimeouts map[string]time.Duration `yaml:"tool_timeouts"`
+}
+
+// resolveEnabled defaults an unset enabled flag to whether the provider has what it needs,
+// and rejects a provider explicitly enabled without its credentials.
+func resolveEnabled(enabled **bool, hasCredentials bool, requirement string) error {
+	if *enabled == nil {
+		*enabled = &hasCredentials
+		return nil
+	}
+	if **enabled && !hasCredentials {
+		return fmt.Errorf("%s is required", requirement)
+	}
+	return nil
 }
 
 func (c *Config) validate() error {
@@ -23,26 +110,92 @@ func (c *Config) validate() error {
 		c.Port = 8080
 	}
 
-	if c.TMDBAPIKey == "" {
-		return fmt.Errorf("TMDB_API_KEY is required")
+	if err := resolveEnabled(&c.TMDBEnabled, c.TMDBAPIKey != "", "TMDB_API_KEY"); err != nil {
+		return err
 	}
 	if c.TMDBResponseLanguage == "" {
 		// default language is zh-CN
 		c.TMDBResponseLanguage = "zh-CN"
 	}
 
-	if c.ThePornDBAPIToken == "" {
-		return fmt.Errorf("ThePornDB_API_KEY is required")
+	if err := resolveEnabled(&c.ThePornDBEnabled, c.ThePornDBAPIToken != "", "ThePornDB_API_KEY"); err != nil {
+		return err
 	}
-	if c.MetaTubeAPIURL == "" {
-		return fmt.Errorf("MetaTube_API_URL is required")
+	if c.MediaRoot != "" {
+		info, err := os.Stat(c.MediaRoot)
+		if err != nil {
+			return fmt.Errorf("invalid MEDIA_ROOT: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("invalid MEDIA_ROOT: %s is not a directory", c.MediaRoot)
+		}
 	}
 	// MetaTube_API_KEY is optional
+	if err := resolveEnabled(&c.MetaTubeEnabled, c.MetaTubeAPIURL != "", "MetaTube_API_URL"); err != nil {
+		return err
+	}
 
+	// DuckDuckGo, fetch and Wikipedia need no credentials, they are on unless disabled.
+	for _, enabled := range []**bool{&c.DuckDuckGoEnabled, &c.FetchEnabled, &c.WikipediaEnabled} {
+		if *enabled == nil {
+			on := true
+			*enabled = &on
+		}
+	}
+	if err := c.validateWebSearchBackends(); err != nil {
+		return err
+	}
 	if c.WikipediaLanguage == "" {
 		// default language is zh
 		c.WikipediaLanguage = "zh"
 	}
+
+	if c.ToolTimeout == 0 {
+		c.ToolTimeout = 2 * time.Minute
+	}
+
+	if c.Cache.MaxEntries == 0 {
+		c.Cache.MaxEntries = 1000
+	}
+	if c.Cache.DirMaxEntries == 0 {
+		c.Cache.DirMaxEntries = 10000
+	}
+	for _, ttl := range []*time.Duration{&c.Cache.TMDBTTL, &c.Cache.ThePornDBTTL, &c.Cache.MetaTubeTTL} {
+		if *ttl == 0 {
+			*ttl = 24 * time.Hour
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateWebSearchBackends() error {
+	available := map[string]bool{
+		"searxng":    c.SearXNGURL != "",
+		"brave":      c.BraveAPIKey != "",
+		"duckduckgo": *c.DuckDuckGoEnabled,
+	}
+	if len(c.WebSearchBackends) == 0 {
+		for _, name := range []string{"searxng", "brave", "duckduckgo"} {
+			if available[name] {
+				c.WebSearchBackends = append(c.WebSearchBackends, name)
+			}
+		}
+		return nil
+	}
+	for _, name := range c.WebSearchBackends {
+		ok, known := available[name]
+		switch {
+		case !known:
+			return fmt.Errorf("unknown web search backend %q, must be one of duckduckgo, searxng or brave", name)
+		case ok:
+		case name == "searxng":
+			return fmt.Errorf("SEARXNG_URL is required by web search backend searxng")
+		case name == "brave":
+			return fmt.Errorf("BRAVE_API_KEY is required by web search backend brave")
+		default:
+			return fmt.Errorf("web search backend %s is disabled", name)
+		}
+	}
 	return nil
 }
 
@@ -63,6 +216,30 @@ func ReadConfig(path string) (*Config, error) {
 	return conf, nil
 }
 
+// boolFromEnv returns nil when the variable is unset so validate can apply the default.
+func boolFromEnv(name string) (*bool, error) {
+	s := os.Getenv(name)
+	if s == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s environment variable: %w", name, err)
+	}
+	return &b, nil
+}
+
+// listFromEnv splits a comma separated variable, e.g. "searxng,duckduckgo".
+func listFromEnv(name string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func ReadConfigFromEnv() (*Config, error) {
 	conf := &Config{}
 
@@ -78,6 +255,105 @@ func ReadConfigFromEnv() (*Config, error) {
 	conf.MetaTubeAPIURL = os.Getenv("METATUBE_API_URL")
 	conf.MetaTubeAPIKEY = os.Getenv("METATUBE_API_KEY")
 	conf.WikipediaLanguage = os.Getenv("WIKIPEDIA_LANGUAGE")
+	conf.SearXNGURL = os.Getenv("SEARXNG_URL")
+	conf.BraveAPIKey = os.Getenv("BRAVE_API_KEY")
+	conf.MediaRoot = os.Getenv("MEDIA_ROOT")
+	conf.WebSearchBackends = listFromEnv("WEB_SEARCH_BACKENDS")
+
+	for name, dst := range map[string]**bool{
+		"TMDB_ENABLED":       &conf.TMDBEnabled,
+		"TPDB_ENABLED":       &conf.ThePornDBEnabled,
+		"METATUBE_ENABLED":   &conf.MetaTubeEnabled,
+		"DUCKDUCKGO_ENABLED": &conf.DuckDuckGoEnabled,
+		"FETCH_ENABLED":      &conf.FetchEnabled,
+		"WIKIPEDIA_ENABLED":  &conf.WikipediaEnabled,
+	} {
+		enabled, err := boolFromEnv(name)
+		if err != nil {
+			return nil, err
+		}
+		*dst = enabled
+	}
+
+	if s := os.Getenv("CACHE_MAX_ENTRIES"); s != "" {
+		_, err := fmt.Sscanf(s, "%d", &conf.Cache.MaxEntries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CACHE_MAX_ENTRIES environment variable: %w", err)
+		}
+	}
+	conf.Cache.Dir = os.Getenv("CACHE_DIR")
+	if s := os.Getenv("CACHE_DIR_MAX_ENTRIES"); s != "" {
+		_, err := fmt.Sscanf(s, "%d", &conf.Cache.DirMaxEntries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CACHE_DIR_MAX_ENTRIES environment variable: %w", err)
+		}
+	}
+	if s := os.Getenv("FETCH_MAX_DOWNLOAD_SIZE"); s != "" {
+		size, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FETCH_MAX_DOWNLOAD_SIZE environment variable: %w", err)
+		}
+		conf.Fetch.MaxDownloadSize = size
+	}
+	conf.Fetch.AllowedDomains = listFromEnv("FETCH_ALLOWED_DOMAINS")
+	conf.Fetch.DeniedDomains = listFromEnv("FETCH_DENIED_DOMAINS")
+	if s := os.Getenv("FETCH_ALLOW_PRIVATE_NETWORKS"); s != "" {
+		allow, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FETCH_ALLOW_PRIVATE_NETWORKS environment variable: %w", err)
+		}
+		conf.Fetch.AllowPrivateNetworks = allow
+	}
+	for name, dst := range map[string]*time.Duration{
+		"TMDB_CACHE_TTL":     &conf.Cache.TMDBTTL,
+		"TPDB_CACHE_TTL":     &conf.Cache.ThePornDBTTL,
+		"METATUBE_CACHE_TTL": &conf.Cache.MetaTubeTTL,
+	} {
+		if s := os.Getenv(name); s != "" {
+			ttl, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s environment variable: %w", name, err)
+			}
+			*dst = ttl
+		}
+	}
+
+	if s := os.Getenv("TOOL_TIMEOUT"); s != "" {
+		timeout, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOOL_TIMEOUT environment variable: %w", err)
+		}
+		conf.ToolTimeout = timeout
+	}
+	// TOOL_TIMEOUTS is a comma separated list of tool=duration, e.g. "fetch=30s,search_movies=1m".
+	if s := os.Getenv("TOOL_TIMEOUTS"); s != "" {
+		conf.ToolTimeouts = map[string]time.Duration{}
+		for _, pair := range strings.Split(s, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			timeout, err := time.ParseDuration(value)
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid TOOL_TIMEOUTS environment variable entry %q", pair)
+			}
+			conf.ToolTimeouts[name] = timeout
+		}
+	}
+
+	for prefix, dst := range map[string]*HTTPConfig{
+		"TMDB":       &conf.TMDBHTTP,
+		"TPDB":       &conf.ThePornDBHTTP,
+		"METATUBE":   &conf.MetaTubeHTTP,
+		"DUCKDUCKGO": &conf.DuckDuckGoHTTP,
+		"FETCH":      &conf.FetchHTTP,
+		"WIKIPEDIA":  &conf.WikipediaHTTP,
+		"SEARXNG":    &conf.SearXNGHTTP,
+		"BRAVE":      &conf.BraveHTTP,
+	} {
+		httpConf, err := httpConfigFromEnv(prefix)
+		if err != nil {
+			return nil, err
+		}
+		*dst = httpConf
+	}
 
 	err := conf.validate()
 	if err != nil {
@@ -85,3 +361,25 @@ func ReadConfigFromEnv() (*Config, error) {
 	}
 	return conf, nil
 }
+
+// httpConfigFromEnv reads <prefix>_HTTP_TIMEOUT, <prefix>_HTTP_MAX_RETRIES and <prefix>_HTTP_PROXY.
+func httpConfigFromEnv(prefix string) (HTTPConfig, error) {
+	conf := HTTPConfig{
+		Proxy: os.Getenv(prefix + "_HTTP_PROXY"),
+	}
+	if s := os.Getenv(prefix + "_HTTP_TIMEOUT"); s != "" {
+		timeout, err := time.ParseDuration(s)
+		if err != nil {
+			return HTTPConfig{}, fmt.Errorf("invalid %s_HTTP_TIMEOUT environment variable: %w", prefix, err)
+		}
+		conf.Timeout = timeout
+	}
+	if s := os.Getenv(prefix + "_HTTP_MAX_RETRIES"); s != "" {
+		retries, err := strconv.Atoi(s)
+		if err != nil {
+			return HTTPConfig{}, fmt.Errorf("invalid %s_HTTP_MAX_RETRIES environment variable: %w", prefix, err)
+		}
+		conf.MaxRetries = retries
+	}
+	return conf, nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestValidateProviders(t *testing.T) {
+	tests := []struct {
+		name          string
+		conf          Config
+		wantTMDB      bool
+		wantTPDB      bool
+		wantMetatube  bool
+		wantWikipedia bool
+	}{
+		{
+			name:          "no credentials",
+			conf:          Config{},
+			wantWikipedia: true,
+		},
+		{
+			name:          "tmdb only",
+			conf:          Config{TMDBAPIKey: "key"},
+			wantTMDB:      true,
+			wantWikipedia: true,
+		},
+		{
+			name: "credentials present but disabled",
+			conf: Config{
+				TMDBAPIKey:        "key",
+				ThePornDBAPIToken: "token",
+				MetaTubeAPIURL:    "http://metatube",
+				ThePornDBEnabled:  boolPtr(false),
+				WikipediaEnabled:  boolPtr(false),
+			},
+			wantTMDB:     true,
+			wantMetatube: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := tt.conf
+			require.NoError(t, conf.validate())
+			assert.Equal(t, tt.wantTMDB, *conf.TMDBEnabled)
+			assert.Equal(t, tt.wantTPDB, *conf.ThePornDBEnabled)
+			assert.Equal(t, tt.wantMetatube, *conf.MetaTubeEnabled)
+			assert.Equal(t, tt.wantWikipedia, *conf.WikipediaEnabled)
+		})
+	}
+}
+
+func TestValidateEnabledWithoutCredentials(t *testing.T) {
+	tests := []struct {
+		name string
+		conf Config
+		want string
+	}{
+		{
+			name: "tmdb",
+			conf: Config{TMDBEnabled: boolPtr(true)},
+			want: "TMDB_API_KEY is required",
+		},
+		{
+			name: "theporndb",
+			conf: Config{ThePornDBEnabled: boolPtr(true)},
+			want: "ThePornDB_API_KEY is required",
+		},
+		{
+			name: "metatube",
+			conf: Config{MetaTubeEnabled: boolPtr(true)},
+			want: "MetaTube_API_URL is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := tt.conf
+			err := conf.validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidateWebSearchBackends(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    Config
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "duckduckgo by default",
+			conf: Config{},
+			want: []string{"duckduckgo"},
+		},
+		{
+			name: "keyed backends first",
+			conf: Config{BraveAPIKey: "key", SearXNGURL: "http://searxng"},
+			want: []string{"searxng", "brave", "duckduckgo"},
+		},
+		{
+			name: "duckduckgo disabled",
+			conf: Config{DuckDuckGoEnabled: boolPtr(false)},
+		},
+		{
+			name: "explicit order",
+			conf: Config{SearXNGURL: "http://searxng", WebSearchBackends: []string{"duckduckgo", "searxng"}},
+			want: []string{"duckduckgo", "searxng"},
+		},
+		{
+			name:    "unknown backend",
+			conf:    Config{WebSearchBackends: []string{"google"}},
+			wantErr: `unknown web search backend "google"`,
+		},
+		{
+			name:    "searxng without url",
+			conf:    Config{WebSearchBackends: []string{"searxng"}},
+			wantErr: "SEARXNG_URL is required",
+		},
+		{
+			name:    "brave without key",
+			conf:    Config{WebSearchBackends: []string{"brave", "duckduckgo"}},
+			wantErr: "BRAVE_API_KEY is required",
+		},
+		{
+			name:    "disabled duckduckgo listed",
+			conf:    Config{DuckDuckGoEnabled: boolPtr(false), WebSearchBackends: []string{"duckduckgo"}},
+			wantErr: "web search backend duckduckgo is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := tt.conf
+			err := conf.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, conf.WebSearchBackends)
+		})
+	}
+}
+
+func TestValidateMediaRoot(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "video.mp4")
+	require.NoError(t, os.WriteFile(file, []byte("video"), 0o644))
+
+	tests := []struct {
+		name      string
+		mediaRoot string
+		want      string
+	}{
+		{
+			name:      "directory",
+			mediaRoot: dir,
+		},
+		{
+			name:      "missing",
+			mediaRoot: filepath.Join(dir, "missing"),
+			want:      "invalid MEDIA_ROOT",
+		},
+		{
+			name:      "file",
+			mediaRoot: file,
+			want:      "is not a directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Config{MediaRoot: tt.mediaRoot}
+			err := conf.validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
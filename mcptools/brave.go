@@ -0,0 +1,103 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	braveSearchURL = "https://api.search.brave.com/res/v1/web/search"
+	// The API returns at most 20 results per request.
+	braveMaxResults = 20
+)
+
+var (
+	braveFreshness = map[string]string{"day": "pd", "week": "pw", "month": "pm", "year": "py"}
+	// Brave highlights query terms in descriptions with <strong>.
+	braveHTMLTag = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Brave is a SearchBackend using the keyed Brave Search API.
+type Brave struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewBrave(apiKey string, client *http.Client) *Brave {
+	return &Brave{
+		apiKey: apiKey,
+		client: httpClientOrDefault(client),
+	}
+}
+
+func (s *Brave) Name() string {
+	return "brave"
+}
+
+type braveSearchResponse struct {
+	Web struct {
+		Results []struct {
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Description string `json:"description"`
+		} `json:"results"`
+	} `json:"web"`
+}
+
+func braveText(s string) string {
+	return html.UnescapeString(braveHTMLTag.ReplaceAllString(s, ""))
+}
+
+func (s *Brave) Search(ctx context.Context, input WebSearchInput) ([]WebSearchResult, error) {
+	params := url.Values{
+		"q":     {input.Query},
+		"count": {strconv.Itoa(min(input.MaxResults, braveMaxResults))},
+	}
+	if country, language := regionParts(input.Region); country != "" {
+		params.Set("country", strings.ToUpper(country))
+		params.Set("search_lang", language)
+	}
+	if input.SafeSearch != "" {
+		params.Set("safesearch", input.SafeSearch)
+	}
+	if input.TimeRange != "" {
+		params.Set("freshness", braveFreshness[input.TimeRange])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, braveSearchURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", s.apiKey)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("brave responded with status code: %d", resp.StatusCode)
+	}
+
+	res := braveSearchResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []WebSearchResult{}
+	for _, item := range res.Web.Results {
+		if result, ok := newWebSearchResult(braveText(item.Title), item.URL, braveText(item.Description)); ok {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
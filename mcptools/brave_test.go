@@ -0,0 +1,38 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBraveSearch(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/res/v1/web/search", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("X-Subscription-Token"))
+		assert.Equal(t, "golang", q.Get("q"))
+		assert.Equal(t, "20", q.Get("count"))
+		assert.Equal(t, "DE", q.Get("country"))
+		assert.Equal(t, "de", q.Get("search_lang"))
+		assert.Equal(t, "off", q.Get("safesearch"))
+		assert.Equal(t, "pd", q.Get("freshness"))
+		_, _ = w.Write([]byte(`{"web":{"results":[
+			{"title":"The Go Programming Language","url":"https://go.dev/","description":"<strong>Go</strong> is fast &amp; simple."}
+		]}}`))
+	}))
+	brave := NewBrave("key", client)
+
+	results, err := brave.Search(t.Context(), WebSearchInput{
+		Query: "golang", Region: "de-de", SafeSearch: "off", TimeRange: "day", MaxResults: 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []WebSearchResult{{
+		Title:   "The Go Programming Language",
+		URL:     "https://go.dev/",
+		Snippet: "Go is fast & simple.",
+		Domain:  "go.dev",
+	}}, results)
+}
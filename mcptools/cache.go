@@ -0,0 +1,344 @@
+package mcptools
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// cacheBypass is the value of the cache input option that skips cached results.
+	cacheBypass = "bypass"
+
+	diskCacheTempPrefix = "tmp-"
+)
+
+// CacheStore stores encoded tool responses until they expire.
+type CacheStore interface {
+	Get(key string) (value []byte, expiresAt time.Time, ok bool)
+	Set(key string, value []byte, expiresAt time.Time)
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-memory LRU CacheStore.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	entry := e.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.ll.Remove(e)
+		delete(c.items, key)
+		return nil, time.Time{}, false
+	}
+	c.ll.MoveToFront(e)
+	return entry.value, entry.expiresAt, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+type diskCacheEntry struct {
+	ExpiresAt time.Time       `json:"expires_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// DiskCache is a CacheStore keeping one file per key in a directory, so it survives restarts.
+// Expired entries are swept on open, and the oldest entries are evicted once there are more
+// than maxEntries.
+type DiskCache struct {
+	dir        string
+	maxEntries int
+
+	mu      sync.Mutex
+	entries int
+	// sweepMu keeps a single sweep running at a time.
+	sweepMu sync.Mutex
+}
+
+// NewDiskCache opens the store in dir, keeping at most maxEntries when it's positive.
+func NewDiskCache(dir string, maxEntries int) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{dir: dir, maxEntries: maxEntries}
+	if err := c.sweep(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// sweep removes expired and unreadable entries, temp files left by interrupted writes, and the
+// oldest entries beyond 90% of maxEntries, so a full cache isn't swept again on every Set.
+func (c *DiskCache) sweep() error {
+	c.sweepMu.Lock()
+	defer c.sweepMu.Unlock()
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+	var files []file
+	now := time.Now()
+	for _, e := range dirEntries {
+		p := filepath.Join(c.dir, e.Name())
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if strings.HasPrefix(e.Name(), diskCacheTempPrefix) {
+			if now.Sub(info.ModTime()) > time.Hour {
+				_ = os.Remove(p)
+			}
+			continue
+		}
+		if filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(p)
+		entry := diskCacheEntry{}
+		if err != nil || json.Unmarshal(data, &entry) != nil || now.After(entry.ExpiresAt) {
+			_ = os.Remove(p)
+			continue
+		}
+		files = append(files, file{path: p, modTime: info.ModTime()})
+	}
+
+	if c.maxEntries > 0 && len(files) > c.maxEntries {
+		slices.SortFunc(files, func(a, b file) int { return a.modTime.Compare(b.modTime) })
+		evict := len(files) - c.maxEntries*9/10
+		for _, f := range files[:evict] {
+			_ = os.Remove(f.path)
+		}
+		files = files[evict:]
+	}
+
+	c.mu.Lock()
+	c.entries = len(files)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *DiskCache) Get(key string) ([]byte, time.Time, bool) {
+	p := c.path(key)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	entry := diskCacheEntry{}
+	if err := json.Unmarshal(data, &entry); err != nil || time.Now().After(entry.ExpiresAt) {
+		if os.Remove(p) == nil {
+			c.mu.Lock()
+			c.entries--
+			c.mu.Unlock()
+		}
+		return nil, time.Time{}, false
+	}
+	return entry.Value, entry.ExpiresAt, true
+}
+
+func (c *DiskCache) Set(key string, value []byte, expiresAt time.Time) {
+	data, err := json.Marshal(diskCacheEntry{ExpiresAt: expiresAt, Value: value})
+	if err != nil {
+		log.Printf("Error encoding cache entry: %v", err)
+		return
+	}
+	// Write to a temp file then rename, so readers never see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, diskCacheTempPrefix+"*")
+	if err != nil {
+		log.Printf("Error writing cache entry: %v", err)
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	p := c.path(key)
+	_, statErr := os.Stat(p)
+	if err == nil {
+		err = os.Rename(tmp.Name(), p)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		log.Printf("Error writing cache entry: %v", err)
+		return
+	}
+
+	if statErr == nil {
+		return
+	}
+	c.mu.Lock()
+	c.entries++
+	full := c.maxEntries > 0 && c.entries > c.maxEntries
+	c.mu.Unlock()
+	if full {
+		if err := c.sweep(); err != nil {
+			log.Printf("Error sweeping disk cache: %v", err)
+		}
+	}
+}
+
+// TieredCache checks stores in order and backfills the faster ones on a hit,
+// e.g. a MemoryCache in front of a DiskCache.
+type TieredCache struct {
+	stores []CacheStore
+}
+
+func NewTieredCache(stores ...CacheStore) *TieredCache {
+	return &TieredCache{stores: stores}
+}
+
+func (c *TieredCache) Get(key string) ([]byte, time.Time, bool) {
+	for i, store := range c.stores {
+		if value, expiresAt, ok := store.Get(key); ok {
+			for _, faster := range c.stores[:i] {
+				faster.Set(key, value, expiresAt)
+			}
+			return value, expiresAt, true
+		}
+	}
+	return nil, time.Time{}, false
+}
+
+func (c *TieredCache) Set(key string, value []byte, expiresAt time.Time) {
+	for _, store := range c.stores {
+		store.Set(key, value, expiresAt)
+	}
+}
+
+// ResponseCache caches the responses of one provider's tools for ttl.
+// A nil *ResponseCache disables caching.
+type ResponseCache struct {
+	store CacheStore
+	ttl   time.Duration
+}
+
+func NewResponseCache(store CacheStore, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{store: store, ttl: ttl}
+}
+
+// cacheKeyTextFields are the free-text title and query inputs normalized in cache keys. Other
+// inputs, such as ids, hashes and paths, can be case-sensitive and are kept as they are.
+var cacheKeyTextFields = map[string]bool{"name": true, "query": true}
+
+// responseCacheKey is built from the tool name, the normalized input and the resolved language.
+// Titles and queries differing only in case or whitespace share an entry, the cache and
+// language options are not part of it.
+func responseCacheKey(tool string, input any, language string) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+	delete(fields, "cache")
+	delete(fields, "language")
+	for k, v := range fields {
+		if s, ok := v.(string); ok && cacheKeyTextFields[k] {
+			fields[k] = strings.ToLower(strings.Join(strings.Fields(s), " "))
+		}
+	}
+	// encoding/json sorts map keys, so the key is stable.
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return tool + "|" + language + "|" + string(data), nil
+}
+
+// cachedCall returns the cached output of tool for input, or calls fn and caches its result.
+// With bypass, fn is always called and its result refreshes the cache.
+func cachedCall[In, Out any](
+	c *ResponseCache, tool string, input In, language string, bypass bool, fn func() (Out, error)) (Out, error) {
+	if c == nil {
+		return fn()
+	}
+
+	key, err := responseCacheKey(tool, input, language)
+	if err != nil {
+		log.Printf("Error building cache key for %s: %v", tool, err)
+		return fn()
+	}
+
+	if !bypass {
+		if data, _, ok := c.store.Get(key); ok {
+			var out Out
+			err := json.Unmarshal(data, &out)
+			if err == nil {
+				return out, nil
+			}
+			log.Printf("Error decoding cached %s response: %v", tool, err)
+		}
+	}
+
+	out, err := fn()
+	if err != nil {
+		return out, err
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		log.Printf("Error encoding %s response for cache: %v", tool, err)
+		return out, nil
+	}
+	c.store.Set(key, data, time.Now().Add(c.ttl))
+	return out, nil
+}
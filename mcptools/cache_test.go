@@ -0,0 +1,219 @@
+package mcptools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	future := time.Now().Add(time.Hour)
+
+	c.Set("a", []byte("1"), future)
+	c.Set("b", []byte("2"), future)
+	// Touch a so b becomes the least recently used.
+	_, _, ok := c.Get("a")
+	require.True(t, ok)
+	c.Set("c", []byte("3"), future)
+
+	_, _, ok = c.Get("b")
+	assert.False(t, ok, "b should be evicted")
+	v, _, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	c.Set("expired", []byte("4"), time.Now().Add(-time.Second))
+	_, _, ok = c.Get("expired")
+	assert.False(t, ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	c.Set("key", []byte(`{"a":1}`), expiresAt)
+
+	// A new store on the same dir sees the entry, as after a restart.
+	c, err = NewDiskCache(dir, 0)
+	require.NoError(t, err)
+	v, got, ok := c.Get("key")
+	require.True(t, ok)
+	assert.JSONEq(t, `{"a":1}`, string(v))
+	assert.WithinDuration(t, expiresAt, got, time.Second)
+
+	c.Set("expired", []byte(`1`), time.Now().Add(-time.Second))
+	_, _, ok = c.Get("expired")
+	assert.False(t, ok)
+}
+
+func TestDiskCacheSweep(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+	c.Set("live", []byte(`1`), time.Now().Add(time.Hour))
+	c.Set("expired", []byte(`1`), time.Now().Add(-time.Second))
+	require.NoError(t, os.WriteFile(c.path("corrupt"), []byte("{"), 0o644))
+	stale := filepath.Join(dir, diskCacheTempPrefix+"1")
+	require.NoError(t, os.WriteFile(stale, nil, 0o644))
+	require.NoError(t, os.Chtimes(stale, time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)))
+
+	// Expired entries are removed on open, not only when their key is read again.
+	_, err = NewDiskCache(dir, 0)
+	require.NoError(t, err)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, filepath.Base(c.path("live")), files[0].Name())
+}
+
+func TestDiskCacheMaxEntries(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 10)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	start := time.Now().Add(-time.Hour)
+	for i := range 10 {
+		key := strconv.Itoa(i)
+		c.Set(key, []byte(`1`), expiresAt)
+		modTime := start.Add(time.Duration(i) * time.Second)
+		require.NoError(t, os.Chtimes(c.path(key), modTime, modTime))
+	}
+	// Going over the bound evicts the oldest entries, down to 90% of it.
+	c.Set("10", []byte(`1`), expiresAt)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 9)
+	for i := range 11 {
+		_, _, ok := c.Get(strconv.Itoa(i))
+		assert.Equal(t, i >= 2, ok, "entry %d", i)
+	}
+}
+
+func TestTieredCacheBackfill(t *testing.T) {
+	memory := NewMemoryCache(10)
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	c := NewTieredCache(memory, disk)
+
+	disk.Set("key", []byte(`"v"`), time.Now().Add(time.Hour))
+	_, _, ok := memory.Get("key")
+	require.False(t, ok)
+
+	v, _, ok := c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, []byte(`"v"`), v)
+	_, _, ok = memory.Get("key")
+	assert.True(t, ok, "memory should be backfilled")
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      any
+		langA     string
+		langB     string
+		wantEqual bool
+	}{
+		{
+			name:      "case and whitespace are normalized",
+			a:         TMDBSearchMovieInput{Name: "The  Matrix "},
+			b:         TMDBSearchMovieInput{Name: "the matrix"},
+			wantEqual: true,
+		},
+		{
+			name: "ids keep their case",
+			a:    TPDBGetPerformerInput{ID: "Jane-Doe"},
+			b:    TPDBGetPerformerInput{ID: "jane-doe"},
+		},
+		{
+			name: "paths keep their case and whitespace",
+			a:    TPDBFindByHashInput{Path: "Videos/My  Scene.mp4"},
+			b:    TPDBFindByHashInput{Path: "videos/my scene.mp4"},
+		},
+		{
+			name:      "cache option is ignored",
+			a:         TMDBSearchMovieInput{Name: "The Matrix"},
+			b:         TMDBSearchMovieInput{Name: "The Matrix", Cache: cacheBypass},
+			wantEqual: true,
+		},
+		{
+			name: "year differs",
+			a:    TMDBSearchMovieInput{Name: "The Matrix", Year: 1999},
+			b:    TMDBSearchMovieInput{Name: "The Matrix"},
+		},
+		{
+			name:  "language differs",
+			a:     TMDBSearchMovieInput{Name: "The Matrix"},
+			b:     TMDBSearchMovieInput{Name: "The Matrix"},
+			langA: "en-US",
+			langB: "zh-CN",
+		},
+		{
+			name:      "language option is covered by the resolved language",
+			a:         TMDBSearchMovieInput{Name: "The Matrix", Language: "en-US"},
+			b:         TMDBSearchMovieInput{Name: "The Matrix"},
+			langA:     "en-US",
+			langB:     "en-US",
+			wantEqual: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := responseCacheKey("search_movies", tt.a, tt.langA)
+			require.NoError(t, err)
+			b, err := responseCacheKey("search_movies", tt.b, tt.langB)
+			require.NoError(t, err)
+			if tt.wantEqual {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a, b)
+			}
+		})
+	}
+}
+
+func TestCachedCall(t *testing.T) {
+	c := NewResponseCache(NewMemoryCache(10), time.Hour)
+	calls := 0
+	fn := func() (SearchMovieOutput, error) {
+		calls++
+		return SearchMovieOutput{Results: []TMDBMovieItem{{Title: "The Matrix"}}}, nil
+	}
+	input := TMDBSearchMovieInput{Name: "The Matrix"}
+
+	out, err := cachedCall(c, "search_movies", input, "en-US", false, fn)
+	require.NoError(t, err)
+	assert.Equal(t, "The Matrix", out.Results[0].Title)
+	out, err = cachedCall(c, "search_movies", input, "en-US", false, fn)
+	require.NoError(t, err)
+	assert.Equal(t, "The Matrix", out.Results[0].Title)
+	assert.Equal(t, 1, calls)
+
+	_, err = cachedCall(c, "search_movies", input, "en-US", true, fn)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "bypass should call upstream")
+
+	// Errors are not cached.
+	failing := func() (SearchMovieOutput, error) {
+		calls++
+		return SearchMovieOutput{}, errors.New("upstream down")
+	}
+	other := TMDBSearchMovieInput{Name: "Heat"}
+	_, err = cachedCall(c, "search_movies", other, "en-US", false, failing)
+	require.Error(t, err)
+	_, err = cachedCall(c, "search_movies", other, "en-US", false, failing)
+	require.Error(t, err)
+	assert.Equal(t, 4, calls)
+}
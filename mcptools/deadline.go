@@ -0,0 +1,32 @@
+package mcptools
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolDeadlineMiddleware bounds every tools/call with the tool's timeout from perTool, or
+// defaultTimeout when it has none. Zero means no deadline. Cancelling the context stops the
+// upstream requests of the call.
+func ToolDeadlineMiddleware(defaultTimeout time.Duration, perTool map[string]time.Duration) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			timeout, ok := perTool[callReq.Params.Name]
+			if !ok {
+				timeout = defaultTimeout
+			}
+			if timeout <= 0 {
+				return next(ctx, method, req)
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, method, req)
+		}
+	}
+}
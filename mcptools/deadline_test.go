@@ -0,0 +1,76 @@
+package mcptools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type waitInput struct{}
+
+type waitOutput struct {
+	Deadline bool `json:"deadline"`
+}
+
+// connectTestServer runs server in memory and returns a connected client session.
+func connectTestServer(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestToolDeadlineMiddleware(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server.AddReceivingMiddleware(ToolDeadlineMiddleware(time.Hour, map[string]time.Duration{
+		"slow":      10 * time.Millisecond,
+		"unbounded": -1,
+	}))
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input waitInput) (
+		*mcp.CallToolResult, waitOutput, error) {
+		_, ok := ctx.Deadline()
+		if req.Params.Name != "slow" {
+			return nil, waitOutput{Deadline: ok}, nil
+		}
+		<-ctx.Done()
+		return nil, waitOutput{}, ctx.Err()
+	}
+	for _, name := range []string{"slow", "default", "unbounded"} {
+		mcp.AddTool(server, &mcp.Tool{Name: name}, handler)
+	}
+	session := connectTestServer(t, server)
+
+	tests := []struct {
+		name         string
+		wantError    bool
+		wantDeadline bool
+	}{
+		{name: "slow", wantError: true},
+		{name: "default", wantDeadline: true},
+		{name: "unbounded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: tt.name})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantError, res.IsError)
+			if !tt.wantError {
+				out, ok := res.StructuredContent.(map[string]any)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantDeadline, out["deadline"])
+			}
+		})
+	}
+}
@@ -2,48 +2,105 @@ package mcptools
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
-	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/tmc/langchaingo/tools/duckduckgo"
+	"github.com/PuerkitoBio/goquery"
 )
 
-const (
-	userAgent          = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/141.0.0.0 Safari/537.36"
-	ddgMaxSearchResult = 10
+const ddgSearchURL = "https://html.duckduckgo.com/html/"
+
+var (
+	ddgSafeSearch = map[string]string{"strict": "1", "moderate": "-1", "off": "-2"}
+	ddgTimeRange  = map[string]string{"day": "d", "week": "w", "month": "m", "year": "y"}
 )
 
+// DuckDuckGo is a SearchBackend scraping the html version of DuckDuckGo, which returns a
+// single page of results.
 type DuckDuckGo struct {
-	tool *duckduckgo.Tool
+	client *http.Client
 }
 
-func NewDuckDuckGo() (*DuckDuckGo, error) {
-	tool, err := duckduckgo.New(ddgMaxSearchResult, userAgent)
-	if err != nil {
-		return nil, err
-	}
+func NewDuckDuckGo(client *http.Client) *DuckDuckGo {
 	return &DuckDuckGo{
-		tool: tool,
-	}, nil
+		client: httpClientOrDefault(client),
+	}
 }
 
-func (s *DuckDuckGo) AddTools(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "web_search",
-		Description: "Performs a web search using DuckDuckGo and returns the search results.",
-	}, s.SearchDuckDuckGoTool)
+func (s *DuckDuckGo) Name() string {
+	return "duckduckgo"
 }
 
-type DuckDuckGoSearchInput struct {
-	Query string `json:"query"`
+// ddgResultURL unwraps the duckduckgo redirect link of a result, e.g.
+// "//duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2F&rut=...".
+func ddgResultURL(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	if target := u.Query().Get("uddg"); target != "" && strings.HasSuffix(u.Path, "/l/") {
+		return target
+	}
+	return href
 }
 
-type DuckDuckGoSearchOutput struct {
-	Results string `json:"results"`
-}
+func (s *DuckDuckGo) Search(ctx context.Context, input WebSearchInput) ([]WebSearchResult, error) {
+	params := url.Values{"q": {input.Query}}
+	if input.Region != "" {
+		params.Set("kl", strings.ToLower(input.Region))
+	}
+	if input.SafeSearch != "" {
+		params.Set("kp", ddgSafeSearch[input.SafeSearch])
+	}
+	if input.TimeRange != "" {
+		params.Set("df", ddgTimeRange[input.TimeRange])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ddgSearchURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("duckduckgo responded with status code: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Rate limited clients get a captcha page instead of results.
+	if doc.Find(".anomaly-modal, #challenge-form").Length() > 0 {
+		return nil, errors.New("duckduckgo responded with a captcha")
+	}
+
+	results := []WebSearchResult{}
+	doc.Find(".result").EachWithBreak(func(_ int, node *goquery.Selection) bool {
+		if len(results) >= input.MaxResults {
+			return false
+		}
+		// Skip sponsored results.
+		if node.HasClass("result--ad") {
+			return true
+		}
+		link := node.Find(".result__a").First()
+		href, ok := link.Attr("href")
+		if !ok {
+			return true
+		}
+		if result, ok := newWebSearchResult(link.Text(), ddgResultURL(href), node.Find(".result__snippet").Text()); ok {
+			results = append(results, result)
+		}
+		return true
+	})
 
-func (s *DuckDuckGo) SearchDuckDuckGoTool(
-	ctx context.Context, req *mcp.CallToolRequest, input DuckDuckGoSearchInput) (
-	*mcp.CallToolResult, DuckDuckGoSearchOutput, error) {
-	res, err := s.tool.Call(ctx, input.Query)
-	return nil, DuckDuckGoSearchOutput{Results: res}, err
+	return results, nil
 }
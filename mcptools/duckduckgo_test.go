@@ -0,0 +1,74 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ddgTestPage = `<html><body>
+<div class="result results_links web-result result--ad">
+  <a class="result__a" href="https://duckduckgo.com/y.js?ad_domain=ads.example">Sponsored</a>
+</div>
+<div class="result results_links web-result">
+  <h2><a class="result__a" href="//duckduckgo.com/l/?uddg=https%3A%2F%2Fgo.dev%2Fdoc%2F&amp;rut=abc">
+    The Go Programming Language
+  </a></h2>
+  <a class="result__snippet" href="#">Go is an <b>open source</b> programming language.</a>
+</div>
+<div class="result results_links web-result">
+  <h2><a class="result__a" href="https://en.wikipedia.org/wiki/Go_(programming_language)">Go - Wikipedia</a></h2>
+  <a class="result__snippet" href="#">Go is a statically typed language.</a>
+</div>
+</body></html>`
+
+func TestDuckDuckGoSearch(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/html/", r.URL.Path)
+		assert.Equal(t, "golang", q.Get("q"))
+		assert.Equal(t, "us-en", q.Get("kl"))
+		assert.Equal(t, "1", q.Get("kp"))
+		assert.Equal(t, "w", q.Get("df"))
+		_, _ = w.Write([]byte(ddgTestPage))
+	}))
+	ddg := NewDuckDuckGo(client)
+
+	results, err := ddg.Search(t.Context(), WebSearchInput{
+		Query: "golang", Region: "US-en", SafeSearch: "strict", TimeRange: "week", MaxResults: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []WebSearchResult{
+		{
+			Title:   "The Go Programming Language",
+			URL:     "https://go.dev/doc/",
+			Snippet: "Go is an open source programming language.",
+			Domain:  "go.dev",
+		},
+		{
+			Title:   "Go - Wikipedia",
+			URL:     "https://en.wikipedia.org/wiki/Go_(programming_language)",
+			Snippet: "Go is a statically typed language.",
+			Domain:  "en.wikipedia.org",
+		},
+	}, results)
+
+	results, err = ddg.Search(t.Context(), WebSearchInput{
+		Query: "golang", Region: "us-en", SafeSearch: "strict", TimeRange: "week", MaxResults: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "go.dev", results[0].Domain)
+}
+
+func TestDuckDuckGoSearchCaptcha(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><div class="anomaly-modal">Unfortunately, bots use DuckDuckGo too.</div></body></html>`))
+	}))
+	ddg := NewDuckDuckGo(client)
+
+	_, err := ddg.Search(t.Context(), WebSearchInput{Query: "golang", MaxResults: 10})
+	require.ErrorContains(t, err, "captcha")
+}
@@ -2,74 +2,272 @@ package mcptools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 
 	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+const (
+	defaultFetchMaxDownloadSize = 10 << 20
+	defaultFetchMaxLength       = 20000
+)
+
+// FetcherOptions configures the fetch tool.
+type FetcherOptions struct {
+	// MaxDownloadSize in bytes, the rest of a larger body is dropped. Zero means defaultFetchMaxDownloadSize.
+	MaxDownloadSize int64
+	// AllowedDomains, when not empty, are the only domains (and their subdomains) fetch may request.
+	AllowedDomains []string
+	// DeniedDomains (and their subdomains) are never requested.
+	DeniedDomains []string
+	// AllowPrivateNetworks turns off the check refusing loopback, private and link-local destinations.
+	AllowPrivateNetworks bool
+}
+
 type Fetcher struct {
+	client          *http.Client
+	maxDownloadSize int64
 }
 
-func NewFetcher() *Fetcher {
-	return &Fetcher{}
+func NewFetcher(client *http.Client, opts FetcherOptions) *Fetcher {
+	maxDownloadSize := opts.MaxDownloadSize
+	if maxDownloadSize <= 0 {
+		maxDownloadSize = defaultFetchMaxDownloadSize
+	}
+	return &Fetcher{
+		client:          withFetchPolicy(httpClientOrDefault(client), opts),
+		maxDownloadSize: maxDownloadSize,
+	}
 }
 
 func (f *Fetcher) AddTools(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fetch",
-		Description: "Fetches content from a specified URL. Can optionally convert HTML content to Markdown.",
+		Description: "Fetches content from a specified URL. Can optionally convert HTML content to Markdown. Can also extract only the main article of a page, or named values by CSS selector or XPath. Text is returned as UTF-8, JSON is pretty-printed, and images and other binary content are only summarized. The final url, redirects, status code and selected headers are returned along with the content. Long content is returned in chunks, use start_index to read the next one.",
 	}, f.fetchTool)
 }
 
 type FetchInput struct {
-	URL               string `json:"url" jsonschema:"the url to fetch"`
-	ConvertToMarkdown bool   `json:"convert_to_markdown" jsonschema:"(optional) whether to convert the content to markdown, default is no"`
+	URL               string            `json:"url" jsonschema:"the url to fetch"`
+	ConvertToMarkdown bool              `json:"convert_to_markdown" jsonschema:"(optional) whether to convert the content to markdown, default is no"`
+	MaxLength         int               `json:"max_length,omitempty" jsonschema:"(optional) the maximum number of characters to return, default is 20000"`
+	StartIndex        int               `json:"start_index,omitempty" jsonschema:"(optional) the character offset to start from, to read the next chunk of a truncated response"`
+	Extract           string            `json:"extract,omitempty" jsonschema:"(optional) set to 'readability' to return only the main article of an HTML page as markdown, along with its title, byline, published date and canonical url"`
+	Selectors         map[string]string `json:"selectors,omitempty" jsonschema:"(optional) values to extract from an HTML page, as a map of name to CSS selector or XPath, e.g. {\"title\": \"h1\", \"cover\": \"img.cover@src\", \"date\": \"//time/@datetime\"}. A CSS selector returns the text of the matching elements, or their attribute when followed by @attr"`
+	SelectorsOnly     bool              `json:"selectors_only,omitempty" jsonschema:"(optional) return only the values extracted by selectors, without the content"`
+	AllowErrorStatus  bool              `json:"allow_error_status,omitempty" jsonschema:"(optional) return the content and status code of non-2xx responses instead of an error"`
+}
+
+// FetchRedirect is a redirect followed by fetch.
+type FetchRedirect struct {
+	URL        string `json:"url" jsonschema:"the url which redirected"`
+	StatusCode int    `json:"status_code"`
 }
 
 type FetchOutput struct {
-	Content string `json:"content"`
+	Content        string `json:"content"`
+	TotalLength    int    `json:"total_length" jsonschema:"the number of characters of the whole content"`
+	Truncated      bool   `json:"truncated" jsonschema:"whether there is content after this chunk, or the download was cut at the size limit"`
+	NextStartIndex int    `json:"next_start_index,omitempty" jsonschema:"the start_index of the next chunk, if any"`
+	ContentType    string `json:"content_type,omitempty" jsonschema:"the media type of the response, images and other binary types are summarized instead of returned"`
+
+	URL           string            `json:"url" jsonschema:"the final url after redirects"`
+	StatusCode    int               `json:"status_code"`
+	ContentLength int64             `json:"content_length,omitempty" jsonschema:"the size of the response body in bytes, if known"`
+	Headers       map[string]string `json:"headers,omitempty" jsonschema:"selected response headers, like Last-Modified, ETag and Content-Language"`
+	Redirects     []FetchRedirect   `json:"redirects,omitempty" jsonschema:"the redirects followed to reach url, oldest first"`
+
+	// Values of each selector, in document order.
+	Extracted map[string][]string `json:"extracted,omitempty"`
+
+	// Set by extract: readability.
+	Title         string `json:"title,omitempty"`
+	Byline        string `json:"byline,omitempty" jsonschema:"the author of the article"`
+	PublishedDate string `json:"published_date,omitempty"`
+	CanonicalURL  string `json:"canonical_url,omitempty"`
 }
 
-func (f *Fetcher) fetch(ctx context.Context, input FetchInput) (string, error) {
+func (f *Fetcher) fetch(ctx context.Context, input FetchInput) (FetchOutput, error) {
+	if input.Extract != "" && input.Extract != fetchExtractReadability {
+		return FetchOutput{}, fmt.Errorf("invalid extract %q, must be readability", input.Extract)
+	}
+	if input.SelectorsOnly && len(input.Selectors) == 0 {
+		return FetchOutput{}, errors.New("selectors_only needs selectors")
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, input.URL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return FetchOutput{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", userAgent)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := f.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch URL: %w", err)
+		return FetchOutput{}, fmt.Errorf("failed to fetch URL: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to fetch URL, status code: %d", resp.StatusCode)
+	if (resp.StatusCode < 200 || resp.StatusCode > 299) && !input.AllowErrorStatus {
+		return FetchOutput{}, fmt.Errorf("failed to fetch URL, status code: %d", resp.StatusCode)
+	}
+
+	// Read one byte past the limit to tell whether the body was cut.
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, f.maxDownloadSize+1))
+	if err != nil {
+		return FetchOutput{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	downloadTruncated := int64(len(bodyBytes)) > f.maxDownloadSize
+	if downloadTruncated {
+		bodyBytes = bodyBytes[:f.maxDownloadSize]
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	output, err := fetchContent(input, resp, bodyBytes, downloadTruncated)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return FetchOutput{}, err
 	}
+	output.URL = resp.Request.URL.String()
+	output.StatusCode = resp.StatusCode
+	switch {
+	case resp.ContentLength >= 0:
+		output.ContentLength = resp.ContentLength
+	case !downloadTruncated:
+		output.ContentLength = int64(len(bodyBytes))
+	}
+	// Otherwise the size is unknown, a cut download of a chunked body never saw its end.
+	output.Headers = selectedHeaders(resp.Header)
+	output.Redirects = redirectChain(resp)
+	return output, nil
+}
 
-	content := string(bodyBytes)
+// fetchContent turns the body of a response into the content of FetchOutput.
+func fetchContent(input FetchInput, resp *http.Response, bodyBytes []byte, downloadTruncated bool) (FetchOutput, error) {
+	mediaType := fetchMediaType(resp.Header.Get("Content-Type"), bodyBytes)
+	if !isTextMediaType(mediaType) {
+		return FetchOutput{
+			Content:     summarizeBinary(mediaType, bodyBytes, downloadTruncated),
+			ContentType: mediaType,
+			Truncated:   downloadTruncated,
+		}, nil
+	}
+	// Text is returned as UTF-8 whatever the charset of the page.
+	content := decodeText(bodyBytes, resp.Header.Get("Content-Type"))
 
-	if input.ConvertToMarkdown {
+	var extracted map[string][]string
+	if len(input.Selectors) > 0 {
+		if !isHTMLMediaType(mediaType) {
+			return FetchOutput{}, fmt.Errorf("selectors need an HTML page, got %s", mediaType)
+		}
+		var err error
+		extracted, err = extractSelectors(content, resp.Request.URL, input.Selectors)
+		if err != nil {
+			return FetchOutput{}, err
+		}
+		if input.SelectorsOnly {
+			return FetchOutput{ContentType: mediaType, Truncated: downloadTruncated, Extracted: extracted}, nil
+		}
+	}
+
+	if input.Extract == fetchExtractReadability {
+		if !isHTMLMediaType(mediaType) {
+			return FetchOutput{}, fmt.Errorf("extract readability needs an HTML page, got %s", mediaType)
+		}
+		article, err := extractReadable(content, resp.Request.URL)
+		if err != nil {
+			return FetchOutput{}, err
+		}
+		output := pageContent(article.Markdown, input.StartIndex, input.MaxLength)
+		output.Truncated = output.Truncated || downloadTruncated
+		output.ContentType = mediaType
+		output.Extracted = extracted
+		output.Title = article.Title
+		output.Byline = article.Byline
+		output.PublishedDate = article.PublishedDate
+		output.CanonicalURL = article.CanonicalURL
+		return output, nil
+	}
+
+	switch {
+	case isJSONMediaType(mediaType):
+		content = prettyJSON(content)
+	case input.ConvertToMarkdown && isHTMLMediaType(mediaType):
 		markdown, err := htmltomarkdown.ConvertString(content)
 		if err != nil {
-			return "", fmt.Errorf("failed to convert HTML to markdown: %w", err)
+			return FetchOutput{}, fmt.Errorf("failed to convert HTML to markdown: %w", err)
 		}
 		content = markdown
 	}
 
-	return content, nil
+	output := pageContent(content, input.StartIndex, input.MaxLength)
+	output.Truncated = output.Truncated || downloadTruncated
+	output.ContentType = mediaType
+	output.Extracted = extracted
+	return output, nil
+}
+
+// fetchResponseHeaders are the response headers returned by fetch.
+var fetchResponseHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Language",
+	"ETag",
+	"Expires",
+	"Last-Modified",
+	"Retry-After",
+	"Server",
+	"X-Robots-Tag",
+}
+
+func selectedHeaders(header http.Header) map[string]string {
+	var selected map[string]string
+	for _, name := range fetchResponseHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			if selected == nil {
+				selected = map[string]string{}
+			}
+			selected[name] = strings.Join(values, ", ")
+		}
+	}
+	return selected
+}
+
+// redirectChain returns the redirects followed to get resp, oldest first.
+func redirectChain(resp *http.Response) []FetchRedirect {
+	var chain []FetchRedirect
+	for req := resp.Request; req.Response != nil; req = req.Response.Request {
+		chain = append(chain, FetchRedirect{URL: req.Response.Request.URL.String(), StatusCode: req.Response.StatusCode})
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// pageContent returns maxLength characters of content from startIndex on.
+func pageContent(content string, startIndex, maxLength int) FetchOutput {
+	if maxLength <= 0 {
+		maxLength = defaultFetchMaxLength
+	}
+	// Page by characters, not bytes, so multi-byte text is never split.
+	runes := []rune(content)
+	start := min(max(startIndex, 0), len(runes))
+	end := min(start+maxLength, len(runes))
+
+	output := FetchOutput{
+		Content:     string(runes[start:end]),
+		TotalLength: len(runes),
+		Truncated:   end < len(runes),
+	}
+	if output.Truncated {
+		output.NextStartIndex = end
+	}
+	return output
 }
 
 func (f *Fetcher) fetchTool(ctx context.Context, req *mcp.CallToolRequest, input FetchInput) (
 	*mcp.CallToolResult, FetchOutput, error) {
 	result, err := f.fetch(ctx, input)
-	return nil, FetchOutput{Content: result}, err
+	return nil, result, err
 }
@@ -0,0 +1,107 @@
+package mcptools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogs/chardet"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+)
+
+// fetchMediaType returns the media type of a response, sniffing the body when the server
+// didn't declare a specific one.
+func fetchMediaType(contentType string, body []byte) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(body))
+	}
+	return mediaType
+}
+
+func isHTMLMediaType(mediaType string) bool {
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isTextMediaType reports whether a response of mediaType can be returned as text.
+func isTextMediaType(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") || isJSONMediaType(mediaType) || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	switch mediaType {
+	case "application/xml", "application/javascript", "application/ecmascript",
+		"application/x-javascript", "application/yaml", "application/x-yaml", "application/toml",
+		"application/x-ndjson", "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+// lookupEncoding finds the encoding of a charset name as reported by chardet, e.g. "GB-18030".
+func lookupEncoding(name string) encoding.Encoding {
+	if e, _ := charset.Lookup(name); e != nil {
+		return e
+	}
+	e, _ := charset.Lookup(strings.ReplaceAll(name, "-", ""))
+	return e
+}
+
+// decodeText transcodes body to UTF-8. The charset comes from a BOM, the Content-Type header or
+// a meta tag, and is guessed from the content when none of them declares it.
+func decodeText(body []byte, contentType string) string {
+	e, name, certain := charset.DetermineEncoding(body, contentType)
+	// windows-1252 is only the fallback of DetermineEncoding, which looks at the first 1024 bytes.
+	if !certain && name == "windows-1252" {
+		if utf8.Valid(body) {
+			return string(body)
+		}
+		if result, err := chardet.NewTextDetector().DetectBest(body); err == nil {
+			if detected := lookupEncoding(result.Charset); detected != nil {
+				e = detected
+			}
+		}
+	}
+	decoded, err := e.NewDecoder().Bytes(body)
+	if err != nil {
+		return string(body)
+	}
+	return string(decoded)
+}
+
+// prettyJSON indents content when it is valid JSON, and returns it unchanged otherwise.
+func prettyJSON(content string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(content), "", "  "); err != nil {
+		return content
+	}
+	return out.String()
+}
+
+// summarizeBinary describes a response which can't be returned as text.
+func summarizeBinary(mediaType string, body []byte, truncated bool) string {
+	size := fmt.Sprintf("%d bytes", len(body))
+	if truncated {
+		size = "more than " + size
+	}
+	if strings.HasPrefix(mediaType, "image/") {
+		if config, format, err := image.DecodeConfig(bytes.NewReader(body)); err == nil {
+			return fmt.Sprintf("%s image, %dx%d pixels, %s. Image content is not returned.",
+				strings.ToUpper(format), config.Width, config.Height, size)
+		}
+		return fmt.Sprintf("%s image, %s. Image content is not returned.", mediaType, size)
+	}
+	return fmt.Sprintf("Binary content of type %s, %s. Binary content is not returned.", mediaType, size)
+}
@@ -0,0 +1,70 @@
+package mcptools
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-shiori/go-readability"
+	"golang.org/x/net/html"
+)
+
+const fetchExtractReadability = "readability"
+
+// readableArticle is the main content of a page, without navigation, footers and the like.
+type readableArticle struct {
+	Title         string
+	Byline        string
+	PublishedDate string
+	CanonicalURL  string
+	// Markdown of the article body.
+	Markdown string
+}
+
+// canonicalURL returns the canonical link of the page, then og:url, then the url it was fetched from.
+func canonicalURL(doc *goquery.Document, pageURL *url.URL) string {
+	for _, selector := range []string{`link[rel="canonical"]`, `meta[property="og:url"]`} {
+		node := doc.Find(selector).First()
+		href := node.AttrOr("href", node.AttrOr("content", ""))
+		if href == "" {
+			continue
+		}
+		if u, err := pageURL.Parse(href); err == nil {
+			return u.String()
+		}
+	}
+	return pageURL.String()
+}
+
+// extractReadable takes the page already decoded to UTF-8.
+func extractReadable(page string, pageURL *url.URL) (readableArticle, error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return readableArticle{}, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	// Readability modifies the document, look up the canonical url first.
+	canonical := canonicalURL(goquery.NewDocumentFromNode(doc), pageURL)
+
+	article, err := readability.FromDocument(doc, pageURL)
+	if err != nil {
+		return readableArticle{}, fmt.Errorf("failed to extract readable content: %w", err)
+	}
+	markdown, err := htmltomarkdown.ConvertString(article.Content)
+	if err != nil {
+		return readableArticle{}, fmt.Errorf("failed to convert HTML to markdown: %w", err)
+	}
+
+	readable := readableArticle{
+		Title:        article.Title,
+		Byline:       article.Byline,
+		CanonicalURL: canonical,
+		Markdown:     markdown,
+	}
+	if article.PublishedTime != nil {
+		readable.PublishedDate = article.PublishedTime.Format(time.RFC3339)
+	}
+	return readable, nil
+}
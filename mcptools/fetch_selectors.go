@@ -0,0 +1,174 @@
+package mcptools
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+)
+
+// attrSuffixPattern matches the "@attr" suffix of a CSS selector, e.g. "img.cover@src".
+var attrSuffixPattern = regexp.MustCompile(`^(.+)@([A-Za-z_:][-A-Za-z0-9_:.]*)$`)
+
+var (
+	// xpathFunctionStepPattern matches a function call used as a location step, e.g.
+	// "/string(//h1)". XPath 1.0 has no such steps, they silently match nothing.
+	xpathFunctionStepPattern = regexp.MustCompile(`/\s*([A-Za-z][-A-Za-z0-9]*)\s*\(`)
+	// xpathLiteralPattern matches the string literals of an XPath expression.
+	xpathLiteralPattern = regexp.MustCompile(`'[^']*'|"[^"]*"`)
+	// xpathFunctionCallPattern matches an expression starting with a function call, e.g.
+	// "count(//a)". A CSS selector never has a parenthesis right after a name.
+	xpathFunctionCallPattern = regexp.MustCompile(`^[A-Za-z][-A-Za-z0-9]*\s*\(`)
+)
+
+// isXPath reports whether a selector is an XPath expression rather than a CSS selector: a
+// path like "//h1", ".//div" or "../a", a parenthesized expression or a function call.
+func isXPath(selector string) bool {
+	for _, prefix := range []string{"/", "./", "..", "("} {
+		if strings.HasPrefix(selector, prefix) {
+			return true
+		}
+	}
+	return xpathFunctionCallPattern.MatchString(selector)
+}
+
+// isURLAttr reports whether values of the attribute are urls, resolved against the page url.
+func isURLAttr(attr string) bool {
+	switch strings.ToLower(attr) {
+	case "href", "src", "poster", "data-src":
+		return true
+	}
+	return false
+}
+
+// selectorValue returns the value of an extracted attribute, or the text of an element with
+// its whitespace collapsed.
+func selectorValue(text, attr string, pageURL *url.URL) string {
+	if attr == "" {
+		return strings.Join(strings.Fields(text), " ")
+	}
+	value := strings.TrimSpace(text)
+	if isURLAttr(attr) && value != "" {
+		if u, err := url.Parse(value); err == nil {
+			return pageURL.ResolveReference(u).String()
+		}
+	}
+	return value
+}
+
+// selectCSS returns the text of the elements matching a CSS selector, or their attribute with
+// the "selector@attr" form.
+func selectCSS(doc *html.Node, selector string, pageURL *url.URL) ([]string, error) {
+	attr := ""
+	if m := attrSuffixPattern.FindStringSubmatch(selector); m != nil {
+		selector, attr = m[1], m[2]
+	}
+	sel, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	for _, node := range sel.MatchAll(doc) {
+		text := ""
+		if attr == "" {
+			text = htmlquery.InnerText(node)
+		} else {
+			var ok bool
+			if text, ok = nodeAttr(node, attr); !ok {
+				continue
+			}
+		}
+		if value := selectorValue(text, attr, pageURL); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// selectXPath returns the text of the nodes matching an XPath expression, e.g. "//h1" or
+// "//img/@src". Expressions evaluating to a number, string or boolean, e.g. "count(//a)",
+// return that value.
+func selectXPath(doc *html.Node, expr string, pageURL *url.URL) ([]string, error) {
+	// Literals like '/foo(bar)' are not steps.
+	steps := xpathLiteralPattern.ReplaceAllString(expr, "''")
+	for _, m := range xpathFunctionStepPattern.FindAllStringSubmatch(steps, -1) {
+		switch m[1] {
+		case "node", "text", "comment", "processing-instruction":
+		default:
+			return nil, fmt.Errorf("function %s() can't be a location step, call it on the path instead, e.g. %s(//h1)", m[1], m[1])
+		}
+	}
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := compiled.Evaluate(htmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		// Node sets are selected below, htmlquery also returns their attribute nodes.
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case string:
+		if value := selectorValue(v, "", pageURL); value != "" {
+			return []string{value}, nil
+		}
+		return []string{}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported XPath result %T", v)
+	}
+
+	values := []string{}
+	for _, node := range htmlquery.QuerySelectorAll(doc, compiled) {
+		attr := ""
+		// htmlquery returns attributes as parentless elements named after the attribute.
+		if node.Type == html.ElementNode && node.Parent == nil {
+			attr = node.Data
+		}
+		if value := selectorValue(htmlquery.InnerText(node), attr, pageURL); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+func nodeAttr(node *html.Node, name string) (string, bool) {
+	for _, attr := range node.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// extractSelectors runs each named selector against the page, already decoded to UTF-8.
+func extractSelectors(page string, pageURL *url.URL, selectors map[string]string) (map[string][]string, error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	extracted := make(map[string][]string, len(selectors))
+	for name, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		var values []string
+		if isXPath(selector) {
+			values, err = selectXPath(doc, selector, pageURL)
+		} else {
+			values, err = selectCSS(doc, selector, pageURL)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q for %s: %w", selector, name, err)
+		}
+		extracted[name] = values
+	}
+	return extracted, nil
+}
@@ -1,15 +1,26 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"image"
+	"image/png"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
 )
 
+// localFetcherOptions lets the fetcher reach httptest servers on loopback.
+var localFetcherOptions = FetcherOptions{AllowPrivateNetworks: true}
+
 func TestFetcher_fetch(t *testing.T) {
 	t.Run("successful fetch", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -18,14 +29,14 @@ func TestFetcher_fetch(t *testing.T) {
 		}))
 		defer server.Close()
 
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL: server.URL,
 		}
 
-		content, err := fetcher.fetch(t.Context(), input)
+		result, err := fetcher.fetch(t.Context(), input)
 		assert.NoError(t, err)
-		assert.Equal(t, "Hello, World!", content)
+		assert.Equal(t, "Hello, World!", result.Content)
 	})
 
 	t.Run("successful fetch and convert to markdown", func(t *testing.T) {
@@ -35,27 +46,27 @@ func TestFetcher_fetch(t *testing.T) {
 		}))
 		defer server.Close()
 
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL:               server.URL,
 			ConvertToMarkdown: true,
 		}
 
-		content, err := fetcher.fetch(t.Context(), input)
+		result, err := fetcher.fetch(t.Context(), input)
 		assert.NoError(t, err)
-		assert.Contains(t, content, "# Title")
-		assert.Contains(t, content, "Content")
+		assert.Contains(t, result.Content, "# Title")
+		assert.Contains(t, result.Content, "Content")
 	})
 
 	t.Run("failed fetch - bad URL", func(t *testing.T) {
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL: "http://localhost:99999", // Non-existent URL
 		}
 
-		content, err := fetcher.fetch(t.Context(), input)
+		result, err := fetcher.fetch(t.Context(), input)
 		assert.Error(t, err)
-		assert.Empty(t, content)
+		assert.Empty(t, result.Content)
 		assert.Contains(t, err.Error(), "failed to fetch URL")
 	})
 
@@ -65,14 +76,14 @@ func TestFetcher_fetch(t *testing.T) {
 		}))
 		defer server.Close()
 
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL: server.URL,
 		}
 
-		content, err := fetcher.fetch(t.Context(), input)
+		result, err := fetcher.fetch(t.Context(), input)
 		assert.Error(t, err)
-		assert.Empty(t, content)
+		assert.Empty(t, result.Content)
 		assert.Contains(t, err.Error(), "failed to fetch URL, status code: 404")
 	})
 }
@@ -85,7 +96,7 @@ func TestFetcher_fetchTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL: server.URL,
 		}
@@ -100,7 +111,7 @@ func TestFetcher_fetchTool(t *testing.T) {
 	})
 
 	t.Run("failed tool call - fetch error", func(t *testing.T) {
-		fetcher := &Fetcher{}
+		fetcher := NewFetcher(nil, localFetcherOptions)
 		input := FetchInput{
 			URL: "http://localhost:99999", // Non-existent URL
 		}
@@ -115,3 +126,461 @@ func TestFetcher_fetchTool(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to fetch URL")
 	})
 }
+
+func TestFetcher_fetchPaging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("你好，世界！Hello"))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name  string
+		input FetchInput
+		want  FetchOutput
+	}{
+		{
+			name:  "whole content",
+			input: FetchInput{URL: server.URL},
+			want:  FetchOutput{Content: "你好，世界！Hello", TotalLength: 11, ContentType: "text/plain"},
+		},
+		{
+			name:  "first chunk",
+			input: FetchInput{URL: server.URL, MaxLength: 4},
+			want:  FetchOutput{Content: "你好，世", TotalLength: 11, Truncated: true, NextStartIndex: 4, ContentType: "text/plain"},
+		},
+		{
+			name:  "last chunk",
+			input: FetchInput{URL: server.URL, MaxLength: 8, StartIndex: 4},
+			want:  FetchOutput{Content: "界！Hello", TotalLength: 11, ContentType: "text/plain"},
+		},
+		{
+			name:  "start past the end",
+			input: FetchInput{URL: server.URL, StartIndex: 100},
+			want:  FetchOutput{TotalLength: 11, ContentType: "text/plain"},
+		},
+	}
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := fetcher.fetch(t.Context(), tt.input)
+			require.NoError(t, err)
+			want := tt.want
+			want.URL = server.URL
+			want.StatusCode = http.StatusOK
+			want.ContentLength = int64(len("你好，世界！Hello"))
+			assert.Equal(t, want, result)
+		})
+	}
+}
+
+func TestFetcher_fetchMaxDownloadSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, FetcherOptions{MaxDownloadSize: 4, AllowPrivateNetworks: true})
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, FetchOutput{
+		Content:       "0123",
+		TotalLength:   4,
+		Truncated:     true,
+		ContentType:   "text/plain",
+		URL:           server.URL,
+		StatusCode:    http.StatusOK,
+		ContentLength: 10,
+	}, result)
+}
+
+func TestFetcher_fetchMaxDownloadSizeChunked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		// Flushing before the end sends the body chunked, without a Content-Length.
+		_, _ = w.Write([]byte("01234"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("56789"))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, FetcherOptions{MaxDownloadSize: 4, AllowPrivateNetworks: true})
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL})
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, "0123", result.Content)
+	// The size is unknown, so it's left out instead of reported as -1.
+	assert.Zero(t, result.ContentLength)
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "content_length")
+}
+
+const readabilityTestPage = `<html>
+<head>
+  <title>Old Title | Example News</title>
+  <link rel="canonical" href="/news/the-article">
+  <meta property="og:title" content="The Article">
+  <meta property="article:published_time" content="2024-05-01T08:00:00Z">
+  <meta name="author" content="Jane Doe">
+</head>
+<body>
+  <nav><a href="/">Home</a> <a href="/news">News</a> <a href="/about">About</a></nav>
+  <div class="cookie-banner"><p>We use cookies to improve your experience.</p><button>Accept</button></div>
+  <article>
+    <h1>The Article</h1>
+    <p>The first paragraph of the article explains what happened, with enough text for the
+    extraction to consider it the main content of the page rather than boilerplate.</p>
+    <p>The second paragraph adds more details and context about the story, keeping the reader
+    informed about everything that matters and nothing that does not.</p>
+    <p>The third paragraph wraps the story up, quoting the people involved and describing what
+    is expected to happen next, so the article is long enough to stand out from the page.</p>
+  </article>
+  <footer>Copyright Example News</footer>
+</body>
+</html>`
+
+func TestFetcher_fetchReadability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(readabilityTestPage))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL + "/news/the-article?utm_source=x", Extract: "readability"})
+	require.NoError(t, err)
+	assert.Equal(t, "The Article", result.Title)
+	assert.Equal(t, "Jane Doe", result.Byline)
+	assert.Equal(t, "2024-05-01T08:00:00Z", result.PublishedDate)
+	assert.Equal(t, server.URL+"/news/the-article", result.CanonicalURL)
+	assert.Contains(t, result.Content, "The first paragraph of the article")
+	assert.NotContains(t, result.Content, "cookies")
+	assert.NotContains(t, result.Content, "Copyright")
+	assert.NotContains(t, result.Content, "About")
+}
+
+func TestFetcher_fetchInvalidExtract(t *testing.T) {
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	_, err := fetcher.fetch(t.Context(), FetchInput{URL: "http://example.com", Extract: "summary"})
+	require.ErrorContains(t, err, `invalid extract "summary"`)
+}
+
+func TestFetcher_fetchCharset(t *testing.T) {
+	encode := func(t *testing.T, e encoding.Encoding, s string) []byte {
+		b, err := e.NewEncoder().Bytes([]byte(s))
+		require.NoError(t, err)
+		return b
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        func(t *testing.T) []byte
+		want        string
+	}{
+		{
+			name:        "gbk from meta tag",
+			contentType: "text/html",
+			body: func(t *testing.T) []byte {
+				return encode(t, simplifiedchinese.GBK, `<html><head><meta charset="gbk"></head><body><p>你好，世界</p></body></html>`)
+			},
+			want: "你好，世界",
+		},
+		{
+			name:        "shift_jis from header",
+			contentType: "text/plain; charset=Shift_JIS",
+			body: func(t *testing.T) []byte {
+				return encode(t, japanese.ShiftJIS, "こんにちは世界")
+			},
+			want: "こんにちは世界",
+		},
+		{
+			name:        "gb18030 detected from content",
+			contentType: "text/plain",
+			body: func(t *testing.T) []byte {
+				return encode(t, simplifiedchinese.GB18030, "北京是中华人民共和国的首都，也是全国的政治中心和文化中心。")
+			},
+			want: "北京是中华人民共和国的首都",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.body(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(body)
+			}))
+			t.Cleanup(server.Close)
+
+			fetcher := NewFetcher(nil, localFetcherOptions)
+			result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL, ConvertToMarkdown: true})
+			require.NoError(t, err)
+			assert.Contains(t, result.Content, tt.want)
+		})
+	}
+}
+
+func TestFetcher_fetchJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"metadata","tags":["a","b"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL, ConvertToMarkdown: true})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", result.ContentType)
+	assert.Equal(t, "{\n  \"name\": \"metadata\",\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}", result.Content)
+
+	_, err = fetcher.fetch(t.Context(), FetchInput{URL: server.URL, Extract: "readability"})
+	require.ErrorContains(t, err, "extract readability needs an HTML page, got application/json")
+}
+
+func TestFetcher_fetchBinary(t *testing.T) {
+	var pngBody bytes.Buffer
+	require.NoError(t, png.Encode(&pngBody, image.NewRGBA(image.Rect(0, 0, 640, 480))))
+
+	tests := []struct {
+		name            string
+		contentType     string
+		body            []byte
+		wantContentType string
+		wantContent     string
+	}{
+		{
+			name:            "png image",
+			contentType:     "image/png",
+			body:            pngBody.Bytes(),
+			wantContentType: "image/png",
+			wantContent:     "PNG image, 640x480 pixels",
+		},
+		{
+			name:            "sniffed image",
+			body:            pngBody.Bytes(),
+			wantContentType: "image/png",
+			wantContent:     "PNG image, 640x480 pixels",
+		},
+		{
+			name:            "zip archive",
+			contentType:     "application/zip",
+			body:            []byte("PK\x03\x04\x00\x00"),
+			wantContentType: "application/zip",
+			wantContent:     "Binary content of type application/zip, 6 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(tt.body)
+			}))
+			t.Cleanup(server.Close)
+
+			fetcher := NewFetcher(nil, localFetcherOptions)
+			result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContentType, result.ContentType)
+			assert.Contains(t, result.Content, tt.wantContent)
+		})
+	}
+}
+
+const selectorsTestPage = `<html>
+<head><title>Scene Catalog</title></head>
+<body>
+  <div class="scene">
+    <h2 class="title"> First   Scene </h2>
+    <img class="cover" src="/covers/1.jpg">
+    <a class="performer" href="/performers/jane">Jane Doe</a>
+    <a class="performer" href="https://example.com/performers/john">John Roe</a>
+    <time datetime="2024-05-01">May 1</time>
+  </div>
+  <div class="scene">
+    <h2 class="title">Second Scene</h2>
+    <img class="cover" src="covers/2.jpg">
+  </div>
+</body>
+</html>`
+
+func TestFetcher_fetchSelectors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(selectorsTestPage))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	selectors := map[string]string{
+		"titles":     "h2.title",
+		"covers":     "img.cover@src",
+		"performers": ".scene a.performer@href",
+		"date":       "//time/@datetime",
+		"first":      "(//h2)[1]",
+		"count":      "count(//h2)",
+		"heading":    "normalize-space(//title)",
+		"has_time":   "(boolean(//time))",
+		"scene":      ".//div[@class='scene'][2]/h2",
+		"literal":    "//a[not(contains(@href, '/x(y)'))]/@href",
+		"missing":    "span.missing",
+	}
+
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL + "/catalog/", Selectors: selectors})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, "Scene Catalog")
+	assert.Equal(t, map[string][]string{
+		"titles":     {"First Scene", "Second Scene"},
+		"covers":     {server.URL + "/covers/1.jpg", server.URL + "/catalog/covers/2.jpg"},
+		"performers": {server.URL + "/performers/jane", "https://example.com/performers/john"},
+		"date":       {"2024-05-01"},
+		"first":      {"First Scene"},
+		"count":      {"2"},
+		"heading":    {"Scene Catalog"},
+		"has_time":   {"true"},
+		"scene":      {"Second Scene"},
+		"literal":    {server.URL + "/performers/jane", "https://example.com/performers/john"},
+		"missing":    {},
+	}, result.Extracted)
+
+	result, err = fetcher.fetch(t.Context(), FetchInput{URL: server.URL, Selectors: map[string]string{"titles": "h2"}, SelectorsOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Content)
+	assert.Equal(t, map[string][]string{"titles": {"First Scene", "Second Scene"}}, result.Extracted)
+}
+
+func TestIsXPath(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "//h1", want: true},
+		{selector: "/html/body", want: true},
+		{selector: "./a", want: true},
+		{selector: ".//div[@class='x']", want: true},
+		{selector: "../a", want: true},
+		{selector: "(//h2)[1]", want: true},
+		{selector: "count(//a)", want: true},
+		{selector: "string(//h1)", want: true},
+		{selector: "normalize-space(//title)", want: true},
+		{selector: "h1", want: false},
+		{selector: ".title", want: false},
+		{selector: "img.cover@src", want: false},
+		{selector: "li:not(.ad)", want: false},
+		{selector: "div > a[href^='/']", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			assert.Equal(t, tt.want, isXPath(tt.selector))
+		})
+	}
+}
+
+func TestFetcher_fetchSelectorsInvalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/json" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(selectorsTestPage))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		input   FetchInput
+		wantErr string
+	}{
+		{
+			name:    "invalid css",
+			input:   FetchInput{URL: server.URL, Selectors: map[string]string{"bad": "div[["}},
+			wantErr: `invalid selector "div[[" for bad`,
+		},
+		{
+			name:    "invalid xpath",
+			input:   FetchInput{URL: server.URL, Selectors: map[string]string{"bad": "//div[@"}},
+			wantErr: `invalid selector "//div[@" for bad`,
+		},
+		{
+			name:    "xpath function step",
+			input:   FetchInput{URL: server.URL, Selectors: map[string]string{"heading": "/string(//h1)"}},
+			wantErr: "function string() can't be a location step",
+		},
+		{
+			name:    "not html",
+			input:   FetchInput{URL: server.URL + "/json", Selectors: map[string]string{"title": "h1"}},
+			wantErr: "selectors need an HTML page, got application/json",
+		},
+		{
+			name:    "selectors only without selectors",
+			input:   FetchInput{URL: server.URL, SelectorsOnly: true},
+			wantErr: "selectors_only needs selectors",
+		},
+	}
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fetcher.fetch(t.Context(), tt.input)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFetcher_fetchResponseMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/login?next=/old", http.StatusFound)
+		case "/login":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Last-Modified", "Wed, 01 May 2024 08:00:00 GMT")
+			w.Header().Set("Content-Language", "en")
+			w.Header().Set("Set-Cookie", "session=secret")
+			_, _ = w.Write([]byte("<p>Please log in</p>"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL + "/old"})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/login?next=/old", result.URL)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, "text/html", result.ContentType)
+	assert.Equal(t, int64(len("<p>Please log in</p>")), result.ContentLength)
+	assert.Equal(t, map[string]string{
+		"Content-Language": "en",
+		"Last-Modified":    "Wed, 01 May 2024 08:00:00 GMT",
+	}, result.Headers)
+	assert.Equal(t, []FetchRedirect{
+		{URL: server.URL + "/old", StatusCode: http.StatusMovedPermanently},
+		{URL: server.URL + "/moved", StatusCode: http.StatusFound},
+	}, result.Redirects)
+}
+
+func TestFetcher_fetchErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("Not available in your region"))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(nil, localFetcherOptions)
+	_, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL})
+	require.ErrorContains(t, err, "status code: 503")
+
+	result, err := fetcher.fetch(t.Context(), FetchInput{URL: server.URL, AllowErrorStatus: true})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+	assert.Equal(t, "Not available in your region", result.Content)
+	assert.Equal(t, map[string]string{"Retry-After": "120"}, result.Headers)
+}
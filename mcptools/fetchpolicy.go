@@ -0,0 +1,146 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// errFetchBlocked is returned for urls the fetch policy doesn't allow.
+var errFetchBlocked = errors.New("blocked by fetch policy")
+
+// nonPublicPrefixes are reserved ranges netip doesn't classify as private.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, embeds IPv4 addresses
+}
+
+// isPublicAddr reports whether addr is a public unicast address, i.e. not loopback,
+// private, link-local (including cloud metadata endpoints), multicast or otherwise reserved.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// denyNonPublicControl is a net.Dialer Control refusing connections to non-public addresses.
+// It sees the resolved address, so DNS rebinding between the policy check and the dial is caught.
+func denyNonPublicControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errFetchBlocked, err)
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s is not a public address", errFetchBlocked, addrPort.Addr())
+	}
+	return nil
+}
+
+// normalizeDomains lowercases domain list entries and drops a leading "*." or trailing dot.
+func normalizeDomains(domains []string) []string {
+	var normalized []string
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*."), ".")
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// matchDomain reports whether host is one of domains or a subdomain of one.
+func matchDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchPolicyTransport checks every request, including each redirect hop, against the fetch
+// policy before passing it on.
+type fetchPolicyTransport struct {
+	base                 http.RoundTripper
+	allowedDomains       []string
+	deniedDomains        []string
+	allowPrivateNetworks bool
+	lookupNetIP          func(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+func (t *fetchPolicyTransport) check(req *http.Request) error {
+	switch req.URL.Scheme {
+	case "http", "https":
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q, only http and https are allowed", errFetchBlocked, req.URL.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(req.URL.Hostname()), ".")
+	if host == "" {
+		return fmt.Errorf("%w: missing host", errFetchBlocked)
+	}
+	if matchDomain(host, t.deniedDomains) {
+		return fmt.Errorf("%w: %s is in the denied domains", errFetchBlocked, host)
+	}
+	if len(t.allowedDomains) > 0 && !matchDomain(host, t.allowedDomains) {
+		return fmt.Errorf("%w: %s is not in the allowed domains", errFetchBlocked, host)
+	}
+
+	if t.allowPrivateNetworks {
+		return nil
+	}
+	addrs := []netip.Addr{}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else {
+		addrs, err = t.lookupNetIP(req.Context(), "ip", host)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+	}
+	for _, addr := range addrs {
+		if !isPublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to non-public address %s", errFetchBlocked, host, addr)
+		}
+	}
+	return nil
+}
+
+func (t *fetchPolicyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.check(req); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withFetchPolicy returns a copy of client applying the policy of opts.
+func withFetchPolicy(client *http.Client, opts FetcherOptions) *http.Client {
+	policyClient := *client
+	base := policyClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	policyClient.Transport = &fetchPolicyTransport{
+		base:                 base,
+		allowedDomains:       normalizeDomains(opts.AllowedDomains),
+		deniedDomains:        normalizeDomains(opts.DeniedDomains),
+		allowPrivateNetworks: opts.AllowPrivateNetworks,
+		lookupNetIP:          net.DefaultResolver.LookupNetIP,
+	}
+	return &policyClient
+}
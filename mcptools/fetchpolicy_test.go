@@ -0,0 +1,140 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLookup resolves names from a fixed table.
+func fakeLookup(hosts map[string]string) func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	return func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		addr, ok := hosts[host]
+		if !ok {
+			return nil, fmt.Errorf("no such host %s", host)
+		}
+		return []netip.Addr{netip.MustParseAddr(addr)}, nil
+	}
+}
+
+type okTransport struct{}
+
+func (okTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestFetchPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{name: "public host", url: "https://example.com/"},
+		{name: "public ip", url: "http://93.184.216.34/"},
+		{name: "metadata endpoint", url: "http://169.254.169.254/latest/meta-data/", wantErr: "non-public address 169.254.169.254"},
+		{name: "localhost", url: "http://localhost:8080/", wantErr: "non-public address 127.0.0.1"},
+		{name: "name resolving to private network", url: "http://intranet.example.com/", wantErr: "non-public address 10.0.0.5"},
+		{name: "ipv6 loopback", url: "http://[::1]/", wantErr: "non-public address ::1"},
+		{name: "ipv4 mapped ipv6", url: "http://[::ffff:192.168.1.1]/", wantErr: "non-public address"},
+		{name: "scheme", url: "file:///etc/passwd", wantErr: `unsupported scheme "file"`},
+		{name: "denied domain", url: "https://tracker.example.org/", wantErr: "tracker.example.org is in the denied domains"},
+		{name: "denied subdomain", url: "https://www.tracker.example.org/", wantErr: "in the denied domains"},
+		{name: "not allowed domain", url: "https://example.net/", wantErr: "example.net is not in the allowed domains"},
+	}
+
+	transport := &fetchPolicyTransport{
+		base:           okTransport{},
+		allowedDomains: normalizeDomains([]string{"example.com", "*.Example.org", "localhost", "93.184.216.34", "169.254.169.254", "::1", "::ffff:192.168.1.1"}),
+		deniedDomains:  normalizeDomains([]string{"tracker.example.org."}),
+		lookupNetIP: fakeLookup(map[string]string{
+			"example.com":          "93.184.216.34",
+			"localhost":            "127.0.0.1",
+			"intranet.example.com": "10.0.0.5",
+		}),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, errFetchBlocked)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestFetchPolicyRedirect(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://metadata.internal/latest/meta-data/", http.StatusFound)
+	}))
+
+	fetcher := NewFetcher(client, FetcherOptions{})
+	fetcher.client.Transport.(*fetchPolicyTransport).lookupNetIP = fakeLookup(map[string]string{
+		"public.example.com": "93.184.216.34",
+		"metadata.internal":  "169.254.169.254",
+	})
+
+	_, err := fetcher.fetch(t.Context(), FetchInput{URL: "http://public.example.com/"})
+	require.ErrorIs(t, err, errFetchBlocked)
+	assert.ErrorContains(t, err, "metadata.internal resolves to non-public address")
+}
+
+func TestHTTPClientBlockPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewHTTPClient(HTTPClientOptions{BlockPrivateNetworks: true})
+	require.NoError(t, err)
+	_, err = client.Get(server.URL)
+	require.ErrorIs(t, err, errFetchBlocked)
+
+	// The configured proxy may be local, only the destination is checked.
+	client, err = NewHTTPClient(HTTPClientOptions{BlockPrivateNetworks: true, Proxy: server.URL})
+	require.NoError(t, err)
+	resp, err := client.Get("http://example.com/")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "8.8.8.8", want: true},
+		{addr: "2606:4700:4700::1111", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.0.1"},
+		{addr: "169.254.169.254"},
+		{addr: "100.64.0.1"},
+		{addr: "0.0.0.0"},
+		{addr: "224.0.0.1"},
+		{addr: "::1"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "::ffff:127.0.0.1"},
+		{addr: "64:ff9b::a00:1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	userAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/141.0.0.0 Safari/537.36"
+
+	defaultHTTPTimeout    = 30 * time.Second
+	defaultHTTPMaxRetries = 2
+	// Give up instead of waiting when upstream asks us to come back later than this.
+	maxRetryAfter = time.Minute
+)
+
+// httpRetryBaseBackoff is the wait before the first retry, doubled on each attempt.
+var httpRetryBaseBackoff = 500 * time.Millisecond
+
+// HTTPClientOptions configures the outbound client of a provider.
+type HTTPClientOptions struct {
+	// Timeout bounds a whole request, including retries. Zero means defaultHTTPTimeout.
+	Timeout time.Duration
+	// MaxRetries on 429 and 5xx responses. Zero means defaultHTTPMaxRetries, negative disables retry.
+	MaxRetries int
+	// Proxy is an http, https or socks5 URL. Empty uses the proxy from environment.
+	Proxy string
+	// BlockPrivateNetworks refuses connections to loopback, private and link-local addresses,
+	// checked on the resolved address of every connection. Connections to the proxy are exempt.
+	BlockPrivateNetworks bool
+}
+
+// NewHTTPClient builds the client shared by all providers: it retries 429/5xx with exponential
+// backoff honoring Retry-After, goes through the configured proxy and sets a consistent User-Agent.
+func NewHTTPClient(opts HTTPClientOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", opts.Proxy, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if opts.BlockPrivateNetworks {
+		transport.DialContext = denyNonPublicDialContext(proxyAddrs(opts.Proxy))
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultHTTPMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &retryTransport{
+			base:       transport,
+			maxRetries: maxRetries,
+		},
+	}, nil
+}
+
+// proxyAddrs returns the host:port the transport dials for the configured or environment proxies.
+func proxyAddrs(proxy string) map[string]bool {
+	proxies := []string{proxy}
+	if proxy == "" {
+		proxies = []string{os.Getenv("HTTP_PROXY"), os.Getenv("http_proxy"), os.Getenv("HTTPS_PROXY"), os.Getenv("https_proxy")}
+	}
+	addrs := map[string]bool{}
+	for _, p := range proxies {
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "://") {
+			p = "http://" + p
+		}
+		u, err := url.Parse(p)
+		if err != nil {
+			continue
+		}
+		port := u.Port()
+		if port == "" {
+			port = map[string]string{"http": "80", "https": "443", "socks5": "1080", "socks5h": "1080"}[u.Scheme]
+		}
+		addrs[net.JoinHostPort(u.Hostname(), port)] = true
+	}
+	return addrs
+}
+
+// denyNonPublicDialContext dials like http.DefaultTransport, refusing non-public addresses
+// except for the proxies, which are configured by the operator and often local.
+func denyNonPublicDialContext(proxies map[string]bool) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	guarded := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: denyNonPublicControl}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if proxies[addr] {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		return guarded.DialContext(ctx, network, addr)
+	}
+}
+
+// defaultHTTPClient is used by providers constructed without a client.
+var defaultHTTPClient, _ = NewHTTPClient(HTTPClientOptions{})
+
+func httpClientOrDefault(client *http.Client) *http.Client {
+	if client == nil {
+		return defaultHTTPClient
+	}
+	return client
+}
+
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+}
+
+func shouldRetryStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header, which is either seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the caller's request.
+	req = req.Clone(req.Context())
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	// A consumed body can only be replayed through GetBody.
+	canRetry := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || !canRetry || attempt >= t.maxRetries || !shouldRetryStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		wait := httpRetryBaseBackoff << attempt
+		if d, ok := retryAfter(resp); ok {
+			if d > maxRetryAfter {
+				return resp, nil
+			}
+			wait = d
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req.Body = body
+		}
+		// Drain so the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// Only log host and path, some providers put their API key in the query.
+		log.Printf("Retrying %s %s%s after status %d in %v (attempt %d/%d)",
+			req.Method, req.URL.Host, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// contextTransport ties requests to ctx on top of their own context, for libraries that
+// don't take a context and build their requests on context.Background.
+type contextTransport struct {
+	base http.RoundTripper
+	ctx  context.Context
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(t.ctx, cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The body is read after RoundTrip returns, keep ctx alive until it is closed.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
@@ -0,0 +1,176 @@
+package mcptools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastRetryBackoff(t *testing.T) {
+	t.Helper()
+	old := httpRetryBaseBackoff
+	httpRetryBaseBackoff = time.Millisecond
+	t.Cleanup(func() { httpRetryBaseBackoff = old })
+}
+
+func TestHTTPClientRetry(t *testing.T) {
+	fastRetryBackoff(t)
+
+	tests := []struct {
+		name         string
+		status       int
+		retryAfter   string
+		maxRetries   int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "retry 503 then succeed",
+			status:       http.StatusServiceUnavailable,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "retry 429 honoring Retry-After",
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "0",
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "Retry-After too long",
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "3600",
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 1,
+		},
+		{
+			name:         "retry disabled",
+			status:       http.StatusBadGateway,
+			maxRetries:   -1,
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:         "no retry on 404",
+			status:       http.StatusNotFound,
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := NewHTTPClient(HTTPClientOptions{MaxRetries: tt.maxRetries})
+			require.NoError(t, err)
+
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			t.Cleanup(func() { resp.Body.Close() })
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestHTTPClientGivesUpAfterMaxRetries(t *testing.T) {
+	fastRetryBackoff(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewHTTPClient(HTTPClientOptions{MaxRetries: 3})
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(4), attempts.Load())
+}
+
+func TestHTTPClientUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewHTTPClient(HTTPClientOptions{})
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, userAgent, got)
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   string
+		wantErr bool
+	}{
+		{name: "http proxy", proxy: "http://127.0.0.1:8080"},
+		{name: "socks5 proxy", proxy: "socks5://127.0.0.1:1080"},
+		{name: "unsupported scheme", proxy: "ftp://127.0.0.1:21", wantErr: true},
+		{name: "invalid url", proxy: "://bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHTTPClient(HTTPClientOptions{Proxy: tt.proxy})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// rewriteHostTransport sends every request to target, so clients of APIs with hardcoded hosts
+// can be tested against a local stand-in.
+type rewriteHostTransport struct {
+	target *url.URL
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// localAPIClient starts handler as a local stand-in and returns a client routing all requests to it.
+func localAPIClient(t *testing.T, handler http.Handler) *http.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &http.Client{Transport: rewriteHostTransport{target: target}}
+}
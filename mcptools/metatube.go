@@ -18,12 +18,16 @@ const (
 type Metatube struct {
 	apiURL string
 	apiKey string
+	client *http.Client
+	cache  *ResponseCache
 }
 
-func NewMetatube(apiURL, apiKey string) *Metatube {
+func NewMetatube(apiURL, apiKey string, client *http.Client, cache *ResponseCache) *Metatube {
 	return &Metatube{
 		apiURL: apiURL,
 		apiKey: apiKey,
+		client: httpClientOrDefault(client),
+		cache:  cache,
 	}
 }
 
@@ -36,6 +40,7 @@ func (s *Metatube) AddTools(server *mcp.Server) {
 
 type SearchJAVInput struct {
 	JAVID string `json:"jav_id" jsonschema:"the id (番号) of the jav to search for, it usually Studio/Label Prefix (usually 3-4 letters) then dash (-) then number. for example: SSIS-698"`
+	Cache string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
 }
 
 type JAV struct {
@@ -92,12 +97,15 @@ func (s *Metatube) searchJAV(ctx context.Context, input SearchJAVInput) (SearchJ
 	if s.apiKey != "" {
 		req.Header.Add("Authorization", "Bearer "+s.apiKey)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return SearchJAVOutput{}, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkProviderResponse("Metatube", "METATUBE_API_KEY", resp); err != nil {
+		return SearchJAVOutput{}, err
+	}
 	res := MetatubeJAVSearchResponse{}
 	err = json.NewDecoder(resp.Body).Decode(&res)
 	if err != nil {
@@ -129,12 +137,18 @@ func (s *Metatube) searchJAV(ctx context.Context, input SearchJAVInput) (SearchJ
 			if s.apiKey != "" {
 				req.Header.Add("Authorization", "Bearer "+s.apiKey)
 			}
-			resp, err := http.DefaultClient.Do(req)
+			resp, err := s.client.Do(req)
 			if err != nil {
 				return SearchJAVOutput{}, err
 			}
 			defer resp.Body.Close()
 
+			// The search result is still useful without details.
+			if err := checkProviderResponse("Metatube", "METATUBE_API_KEY", resp); err != nil {
+				log.Printf("Error fetching details of %s: %v", item.Number, err)
+				results = append(results, jav)
+				continue
+			}
 			detailsRes := MetatubeJAVDetaiisResponse{}
 			err = json.NewDecoder(resp.Body).Decode(&detailsRes)
 			if err != nil {
@@ -155,6 +169,9 @@ func (s *Metatube) searchJAV(ctx context.Context, input SearchJAVInput) (SearchJ
 func (s *Metatube) searchJAVTool(
 	ctx context.Context, req *mcp.CallToolRequest, input SearchJAVInput) (
 	*mcp.CallToolResult, SearchJAVOutput, error) {
-	result, err := s.searchJAV(ctx, input)
+	result, err := cachedCall(s.cache, "search_japanese_porn", input, "", input.Cache == cacheBypass,
+		func() (SearchJAVOutput, error) {
+			return s.searchJAV(ctx, input)
+		})
 	return nil, result, err
 }
@@ -1,6 +1,7 @@
 package mcptools
 
 import (
+	"net/http"
 	"os"
 	"testing"
 
@@ -19,10 +20,51 @@ func metatubeURLFromEnv(t *testing.T) string {
 
 func TestSearchJAV(t *testing.T) {
 	url := metatubeURLFromEnv(t)
-	metatube := NewMetatube(url, "")
+	metatube := NewMetatube(url, "", nil, nil)
 	result, err := metatube.searchJAV(t.Context(), SearchJAVInput{JAVID: "SSIS-698"})
 	require.NoError(t, err)
 	require.NotEmpty(t, result.Results)
 	assert.Equal(t, "SSIS-698", result.Results[0].JAVID)
 	assert.Contains(t, result.Results[0].Actors, "三上悠亜")
 }
+
+func TestSearchJAVLocal(t *testing.T) {
+	detailsStatus := http.StatusOK
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/movies/search":
+			_, _ = w.Write([]byte(`{"data": [{"id": "ssis698", "number": "SSIS-698", "title": "Title", "provider": "AVBASE"}]}`))
+		case "/v1/movies/AVBASE/ssis698":
+			if detailsStatus != http.StatusOK {
+				w.WriteHeader(detailsStatus)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"maker": "S1", "genres": ["Drama"]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	metatube := NewMetatube("http://metatube", "", client, nil)
+
+	result, err := metatube.searchJAV(t.Context(), SearchJAVInput{JAVID: "SSIS-698"})
+	require.NoError(t, err)
+	assert.Equal(t, []JAV{{JAVID: "SSIS-698", Title: "Title", Provider: "AVBASE", Maker: "S1", Tags: []string{"Drama"}}}, result.Results)
+
+	// Failed details still return the search result.
+	detailsStatus = http.StatusServiceUnavailable
+	result, err = metatube.searchJAV(t.Context(), SearchJAVInput{JAVID: "SSIS-698"})
+	require.NoError(t, err)
+	assert.Equal(t, []JAV{{JAVID: "SSIS-698", Title: "Title", Provider: "AVBASE"}}, result.Results)
+}
+
+func TestSearchJAVErrorStatus(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	metatube := NewMetatube("http://metatube", "", client, nil)
+
+	_, err := metatube.searchJAV(t.Context(), SearchJAVInput{JAVID: "SSIS-698"})
+	require.ErrorIs(t, err, errProviderRateLimited)
+	assert.Contains(t, err.Error(), "Metatube: rate limited (status 429), retry after 5s")
+}
@@ -0,0 +1,100 @@
+package mcptools
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// parallelMap calls fn on every item with at most concurrency calls in flight and returns the
+// results in input order. Once ctx is done no new call is started and ctx.Err() is returned.
+func parallelMap[T, R any](ctx context.Context, items []T, concurrency int, fn func(context.Context, T) R) ([]R, error) {
+	results := make([]R, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fn(ctx, item)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// rateLimiter spaces calls evenly so they stay under a requests per second budget.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	return &rateLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the caller's slot, or until ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitedTransport waits on limiter before every request.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withRateLimit makes rt wait on limiter before every request. The retrying transport of
+// NewHTTPClient is rebuilt with the limiter under its retry loop, so each retry takes a slot
+// as well instead of bursting past the limit.
+func withRateLimit(rt http.RoundTripper, limiter *rateLimiter) http.RoundTripper {
+	if retry, ok := rt.(*retryTransport); ok {
+		return &retryTransport{base: withRateLimit(retry.base, limiter), maxRetries: retry.maxRetries}
+	}
+	return &rateLimitedTransport{base: rt, limiter: limiter}
+}
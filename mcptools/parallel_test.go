@@ -0,0 +1,90 @@
+package mcptools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelMap(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	results, err := parallelMap(t.Context(), items, 3, func(ctx context.Context, i int) int {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		// Later items finish first, the order must still be kept.
+		time.Sleep(time.Duration(len(items)-i) * time.Millisecond)
+		inFlight.Add(-1)
+		return i * 10
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 20, 30, 40, 50, 60, 70, 80}, results)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestParallelMapCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	var calls atomic.Int32
+
+	_, err := parallelMap(ctx, []int{1, 2, 3, 4, 5}, 1, func(ctx context.Context, i int) int {
+		calls.Add(1)
+		cancel()
+		return i
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), calls.Load(), "no call should start after cancellation")
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(100)
+	start := time.Now()
+	for range 5 {
+		require.NoError(t, l.Wait(t.Context()))
+	}
+	// First call is immediate, the next four are 10ms apart.
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	slow := newRateLimiter(1)
+	require.NoError(t, slow.Wait(ctx))
+	assert.ErrorIs(t, slow.Wait(ctx), context.Canceled)
+}
+
+func TestWithRateLimitRetries(t *testing.T) {
+	fastRetryBackoff(t)
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewHTTPClient(HTTPClientOptions{})
+	require.NoError(t, err)
+	client.Transport = withRateLimit(client.Transport, newRateLimiter(20))
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts.Load())
+	// Every attempt takes a slot, the retries are 50ms apart instead of the 1ms backoff.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
@@ -0,0 +1,79 @@
+package mcptools
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Kinds of provider errors, match them with errors.Is.
+var (
+	errProviderAuth        = errors.New("authentication failed")
+	errProviderRateLimited = errors.New("rate limited")
+	errProviderUnavailable = errors.New("upstream unavailable")
+	errProviderNotFound    = errors.New("not found")
+	errProviderBadResponse = errors.New("unexpected response")
+)
+
+// providerError is a non-200 response of a metadata provider. Its message ends up in the tool
+// result, so it says what the agent or the operator can do about it.
+type providerError struct {
+	Provider   string
+	StatusCode int
+	Kind       error
+	// RetryAfter is how long the provider asked to wait, if it did.
+	RetryAfter time.Duration
+	Hint       string
+}
+
+func (e *providerError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %v (status %d)", e.Provider, e.Kind, e.StatusCode)
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&b, ", retry after %s", e.RetryAfter)
+	}
+	if e.Hint != "" {
+		b.WriteString(". ")
+		b.WriteString(e.Hint)
+	}
+	return b.String()
+}
+
+func (e *providerError) Unwrap() error {
+	return e.Kind
+}
+
+// checkProviderResponse returns a providerError for a non-200 response. credential is the
+// setting to check on authentication failures, e.g. TPDB_API_TOKEN.
+func checkProviderResponse(provider, credential string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	e := &providerError{Provider: provider, StatusCode: resp.StatusCode}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		e.Kind = errProviderAuth
+		e.Hint = fmt.Sprintf("The %s credentials are missing, invalid or expired, check %s. Retrying will not help.", provider, credential)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.Kind = errProviderRateLimited
+		e.RetryAfter, _ = retryAfter(resp)
+		e.Hint = fmt.Sprintf("Too many requests to %s, wait before calling it again.", provider)
+	case resp.StatusCode == http.StatusNotFound:
+		e.Kind = errProviderNotFound
+		e.Hint = "The requested item does not exist, check the id or search for it instead."
+	case resp.StatusCode >= 500:
+		e.Kind = errProviderUnavailable
+		e.RetryAfter, _ = retryAfter(resp)
+		e.Hint = fmt.Sprintf("%s is down or overloaded, try again later.", provider)
+	default:
+		e.Kind = errProviderBadResponse
+		// Client errors usually explain themselves, keep the start of the body.
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		e.Hint = strings.Join(strings.Fields(string(body)), " ")
+	}
+	return e
+}
@@ -0,0 +1,107 @@
+package mcptools
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckProviderResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		header         http.Header
+		body           string
+		wantKind       error
+		wantRetryAfter time.Duration
+		wantMessage    string
+	}{
+		{
+			name:        "unauthorized",
+			statusCode:  http.StatusUnauthorized,
+			wantKind:    errProviderAuth,
+			wantMessage: "ThePornDB: authentication failed (status 401). The ThePornDB credentials are missing, invalid or expired, check TPDB_API_TOKEN.",
+		},
+		{
+			name:        "forbidden",
+			statusCode:  http.StatusForbidden,
+			wantKind:    errProviderAuth,
+			wantMessage: "check TPDB_API_TOKEN",
+		},
+		{
+			name:           "rate limited",
+			statusCode:     http.StatusTooManyRequests,
+			header:         http.Header{"Retry-After": {"30"}},
+			wantKind:       errProviderRateLimited,
+			wantRetryAfter: 30 * time.Second,
+			wantMessage:    "ThePornDB: rate limited (status 429), retry after 30s. Too many requests",
+		},
+		{
+			name:        "not found",
+			statusCode:  http.StatusNotFound,
+			wantKind:    errProviderNotFound,
+			wantMessage: "ThePornDB: not found (status 404). The requested item does not exist",
+		},
+		{
+			name:        "unavailable",
+			statusCode:  http.StatusBadGateway,
+			wantKind:    errProviderUnavailable,
+			wantMessage: "ThePornDB: upstream unavailable (status 502). ThePornDB is down or overloaded",
+		},
+		{
+			name:        "bad request",
+			statusCode:  http.StatusUnprocessableEntity,
+			body:        `{"message": "The date field must be a valid date."}`,
+			wantKind:    errProviderBadResponse,
+			wantMessage: `ThePornDB: unexpected response (status 422). {"message": "The date field must be a valid date."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Header:     tt.header,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			err := checkProviderResponse("ThePornDB", "TPDB_API_TOKEN", resp)
+			require.ErrorIs(t, err, tt.wantKind)
+			assert.Contains(t, err.Error(), tt.wantMessage)
+
+			var providerErr *providerError
+			require.ErrorAs(t, err, &providerErr)
+			assert.Equal(t, tt.statusCode, providerErr.StatusCode)
+			assert.Equal(t, tt.wantRetryAfter, providerErr.RetryAfter)
+		})
+	}
+
+	require.NoError(t, checkProviderResponse("ThePornDB", "TPDB_API_TOKEN", &http.Response{StatusCode: http.StatusOK}))
+}
+
+func TestProviderErrorToolResult(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Unauthenticated."}`))
+	}))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewThePornDB("expired", "", client, nil).AddTools(server)
+	session := connectTestServer(t, server)
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "search_porn",
+		Arguments: map[string]any{"query": "long con"},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	require.Len(t, res.Content, 1)
+	text, ok := res.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "ThePornDB: authentication failed (status 401)")
+	assert.Contains(t, text.Text, "check TPDB_API_TOKEN")
+}
@@ -0,0 +1,104 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var searxngSafeSearch = map[string]string{"off": "0", "moderate": "1", "strict": "2"}
+
+// SearXNG is a SearchBackend querying the JSON API of a SearXNG instance. The instance must
+// have "json" in search.formats of its settings.yml.
+type SearXNG struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewSearXNG(baseURL string, client *http.Client) *SearXNG {
+	return &SearXNG{
+		baseURL: baseURL,
+		client:  httpClientOrDefault(client),
+	}
+}
+
+func (s *SearXNG) Name() string {
+	return "searxng"
+}
+
+type searxngResponse struct {
+	Results []struct {
+		Title   string `json:"title"`
+		URL     string `json:"url"`
+		Content string `json:"content"`
+	} `json:"results"`
+	// UnresponsiveEngines are [engine, reason] pairs, e.g. ["google", "CAPTCHA"].
+	UnresponsiveEngines [][]string `json:"unresponsive_engines"`
+}
+
+func (s *SearXNG) Search(ctx context.Context, input WebSearchInput) ([]WebSearchResult, error) {
+	u, err := url.Parse(s.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/search"
+	params := url.Values{
+		"q":      {input.Query},
+		"format": {"json"},
+	}
+	if country, language := regionParts(input.Region); language != "" {
+		params.Set("language", language+"-"+strings.ToUpper(country))
+	}
+	if input.SafeSearch != "" {
+		params.Set("safesearch", searxngSafeSearch[input.SafeSearch])
+	}
+	if input.TimeRange != "" {
+		params.Set("time_range", input.TimeRange)
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("searxng responded with status code: %d, is the json format enabled?", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("searxng responded with status code: %d", resp.StatusCode)
+	}
+
+	res := searxngResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+	// SearXNG answers 200 even when all its engines are rate limited or captcha'd.
+	if len(res.Results) == 0 && len(res.UnresponsiveEngines) > 0 {
+		engines := make([]string, 0, len(res.UnresponsiveEngines))
+		for _, engine := range res.UnresponsiveEngines {
+			engines = append(engines, strings.Join(engine, ": "))
+		}
+		return nil, fmt.Errorf("searxng engines unresponsive: %s", strings.Join(engines, ", "))
+	}
+
+	results := []WebSearchResult{}
+	for _, item := range res.Results {
+		if len(results) >= input.MaxResults {
+			break
+		}
+		if result, ok := newWebSearchResult(item.Title, item.URL, item.Content); ok {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,77 @@
+package mcptools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearXNGSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/searxng/search", r.URL.Path)
+		assert.Equal(t, "golang", q.Get("q"))
+		assert.Equal(t, "json", q.Get("format"))
+		assert.Equal(t, "en-US", q.Get("language"))
+		assert.Equal(t, "2", q.Get("safesearch"))
+		assert.Equal(t, "month", q.Get("time_range"))
+		_, _ = w.Write([]byte(`{"results":[
+			{"title":"The Go Programming Language","url":"https://go.dev/","content":"Build simple, secure, scalable systems."},
+			{"title":"No url","url":""},
+			{"title":"Go - Wikipedia","url":"https://en.wikipedia.org/wiki/Go","content":"Go is a language."}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	searxng := NewSearXNG(server.URL+"/searxng/", nil)
+	results, err := searxng.Search(t.Context(), WebSearchInput{
+		Query: "golang", Region: "us-en", SafeSearch: "strict", TimeRange: "month", MaxResults: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []WebSearchResult{
+		{
+			Title:   "The Go Programming Language",
+			URL:     "https://go.dev/",
+			Snippet: "Build simple, secure, scalable systems.",
+			Domain:  "go.dev",
+		},
+		{
+			Title:   "Go - Wikipedia",
+			URL:     "https://en.wikipedia.org/wiki/Go",
+			Snippet: "Go is a language.",
+			Domain:  "en.wikipedia.org",
+		},
+	}, results)
+}
+
+func TestSearXNGJSONDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	searxng := NewSearXNG(server.URL, nil)
+	_, err := searxng.Search(t.Context(), WebSearchInput{Query: "golang", MaxResults: 10})
+	require.ErrorContains(t, err, "json format")
+}
+
+func TestSearXNGUnresponsiveEngines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"unresponsive_engines":[["google","CAPTCHA"],["duckduckgo","too many requests"]]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	searxng := NewSearXNG(server.URL, nil)
+	_, err := searxng.Search(t.Context(), WebSearchInput{Query: "golang", MaxResults: 10})
+	require.ErrorContains(t, err, "searxng engines unresponsive: google: CAPTCHA, duckduckgo: too many requests")
+
+	// web_search moves on to the next backend.
+	working := &fakeSearchBackend{name: "brave", results: []WebSearchResult{{Title: "Go", URL: "https://go.dev/", Domain: "go.dev"}}}
+	result, err := NewWebSearch(searxng, working).search(t.Context(), WebSearchInput{Query: "golang"})
+	require.NoError(t, err)
+	assert.Equal(t, "brave", result.Backend)
+	assert.Equal(t, working.results, result.Results)
+}
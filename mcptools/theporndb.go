@@ -1,10 +1,16 @@
 package mcptools
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -17,19 +23,60 @@ const (
 
 type ThePornDB struct {
 	apiToken string
+	// mediaRoot is the directory find_porn_by_hash may read files from, empty disables it.
+	mediaRoot string
+	client    *http.Client
+	cache     *ResponseCache
 }
 
-func NewThePornDB(apiToken string) *ThePornDB {
+func NewThePornDB(apiToken, mediaRoot string, client *http.Client, cache *ResponseCache) *ThePornDB {
+	// Absolute paths are matched against the media root, which may be configured relative.
+	if mediaRoot != "" {
+		if abs, err := filepath.Abs(mediaRoot); err == nil {
+			mediaRoot = abs
+		}
+	}
 	return &ThePornDB{
-		apiToken: apiToken,
+		apiToken:  apiToken,
+		mediaRoot: mediaRoot,
+		client:    httpClientOrDefault(client),
+		cache:     cache,
 	}
 }
 
 func (s *ThePornDB) AddTools(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_porn",
-		Description: "Searches for non-Japanese pornographic movies and scenes on ThePornDB.",
+		Description: "Searches for non-Japanese pornographic movies and scenes on ThePornDB by name, optionally filtered by site, release date or date range, and performer. Results are paged, use page to read the next one.",
 	}, s.searchTPDBVideosTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_porn_performers",
+		Description: "Searches for performers on ThePornDB by name, returning their aliases, bio, measurements and career years, to tell performers with similar names apart.",
+	}, s.searchPerformersTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_porn_performer",
+		Description: "Gets a performer on ThePornDB by slug, uuid or numeric id.",
+	}, s.getPerformerTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_porn_sites",
+		Description: "Searches for sites and networks on ThePornDB by name, returning the parent site and network of each site.",
+	}, s.searchSitesTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_porn_by_hash",
+		Description: "Finds scenes on ThePornDB by the OSHash or perceptual hash of the video file, which works when the file name is scrambled. Can also compute the OSHash of a file in the media root from its path.",
+	}, s.findByHashTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "parse_porn_filename",
+		Description: "Parses a raw release filename like Site.24.03.15.Performer.Title.1080p.mp4 with ThePornDB, returning the site, date, performers and title found in it and the best matching scenes with a confidence score.",
+	}, s.parseFilenameTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_porn_scene",
+		Description: "Gets a scene on ThePornDB by slug or uuid, with its chapters, performers, file hashes and the movies it is part of.",
+	}, s.getSceneTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_porn_movie",
+		Description: "Gets a movie on ThePornDB by slug or uuid, with its chapters, performers, file hashes and the scenes it contains.",
+	}, s.getMovieTool)
 }
 
 type tpdbActorInfo struct {
@@ -110,101 +157,217 @@ type tpdbVideoInfo struct {
 	Directors []struct {
 		Name string `json:"name"`
 	} `json:"directors"`
+
+	// Only in scene and movie details.
+	Markers []struct {
+		Title     string `json:"title"`
+		StartTime int    `json:"start_time"`
+		EndTime   int    `json:"end_time"`
+	} `json:"markers"`
+	Hashes []struct {
+		Hash     string `json:"hash"`
+		Type     string `json:"type"`
+		Duration int    `json:"duration"`
+	} `json:"hashes"`
+	Movies []tpdbVideoInfo `json:"movies"`
+	Scenes []tpdbVideoInfo `json:"scenes"`
+}
+
+// tpdbPageMeta is the pagination of a ThePornDB list response.
+type tpdbPageMeta struct {
+	CurrentPage int `json:"current_page"`
+	LastPage    int `json:"last_page"`
+	PerPage     int `json:"per_page"`
+	Total       int `json:"total"`
 }
 
 type searchTPDBVideosResponse struct {
 	Data []tpdbVideoInfo `json:"data"`
+	Meta tpdbPageMeta    `json:"meta"`
 }
 
 type TPDBSearchVideosInput struct {
-	Query string `json:"query" jsonschema:"the name of the video to search for, don't include release date and studio prefix, don't use dash or dot spliter"`
+	Query     string `json:"query,omitempty" jsonschema:"the name of the video to search for, don't include release date and studio prefix, don't use dash or dot spliter"`
+	Site      string `json:"site,omitempty" jsonschema:"(optional) the site or studio which released the video"`
+	Date      string `json:"date,omitempty" jsonschema:"(optional) the release date, as YYYY-MM-DD"`
+	DateFrom  string `json:"date_from,omitempty" jsonschema:"(optional) the earliest release date, as YYYY-MM-DD"`
+	DateTo    string `json:"date_to,omitempty" jsonschema:"(optional) the latest release date, as YYYY-MM-DD"`
+	Performer string `json:"performer,omitempty" jsonschema:"(optional) the name of a performer in the video"`
+	Page      int    `json:"page,omitempty" jsonschema:"(optional) the page of results, starting at 1"`
+	Cache     string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+// query returns the ThePornDB query parameters of the input.
+func (input TPDBSearchVideosInput) query() (url.Values, error) {
+	if input.Query == "" && input.Site == "" && input.Date == "" && input.DateFrom == "" && input.DateTo == "" && input.Performer == "" {
+		return nil, errors.New("one of query, site, date, date_from, date_to or performer is required")
+	}
+	if input.Page < 0 {
+		return nil, fmt.Errorf("invalid page %d, must be at least 1", input.Page)
+	}
+
+	q := url.Values{}
+	for _, param := range []struct{ name, value string }{
+		{"q", input.Query},
+		{"site", input.Site},
+		{"performers", input.Performer},
+	} {
+		if param.value != "" {
+			q.Set(param.name, param.value)
+		}
+	}
+	for _, param := range []struct{ name, value string }{
+		{"date", input.Date},
+		{"date_from", input.DateFrom},
+		{"date_to", input.DateTo},
+	} {
+		if param.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, param.value); err != nil {
+			return nil, fmt.Errorf("invalid %s %q, must be YYYY-MM-DD", param.name, param.value)
+		}
+		q.Set(param.name, param.value)
+	}
+	q.Set("page", strconv.Itoa(max(input.Page, 1)))
+	q.Set("per_page", strconv.Itoa(tpdbLimitVideoPerType))
+	return q, nil
+}
+
+type TPDBPerformerItem struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Gender  string   `json:"gender,omitempty"`
 }
 
 type TPDBVideoItem struct {
-	ID          string   `json:"id" jsonschema:"the meaningful id of the video, usually used to rename files."`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Type        string   `json:"type" jsonschema:"scene or movie"`
-	Date        string   `json:"date"`
-	Actors      []string `json:"actors,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
+	ID          string              `json:"id" jsonschema:"the meaningful id of the video, usually used to rename files."`
+	UUID        string              `json:"uuid,omitempty" jsonschema:"the ThePornDB uuid of the video"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Type        string              `json:"type" jsonschema:"scene or movie"`
+	Date        string              `json:"date"`
+	Site        string              `json:"site,omitempty" jsonschema:"the studio or site which released the video"`
+	Duration    int                 `json:"duration,omitempty" jsonschema:"the duration in seconds"`
+	Actors      []string            `json:"actors,omitempty"`
+	Performers  []TPDBPerformerItem `json:"performers,omitempty" jsonschema:"the performers with their aliases and gender"`
+	Directors   []string            `json:"directors,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Poster      string              `json:"poster,omitempty" jsonschema:"the url of the poster image"`
+	Cover       string              `json:"cover,omitempty" jsonschema:"the url of the cover image"`
 }
 type TPDBSearchVideosOutput struct {
-	Results []TPDBVideoItem `json:"results"`
+	Results     []TPDBVideoItem `json:"results"`
+	SceneTotal  int             `json:"scene_total" jsonschema:"the number of matching scenes on all pages"`
+	MovieTotal  int             `json:"movie_total" jsonschema:"the number of matching movies on all pages"`
+	Page        int             `json:"page"`
+	HasNextPage bool            `json:"has_next_page" jsonschema:"whether the next page has more scenes or movies"`
 }
 
-func (s *ThePornDB) search(ctx context.Context, query string, url_ string) ([]TPDBVideoItem, error) {
+func newTPDBVideoItem(info tpdbVideoInfo) TPDBVideoItem {
+	item := TPDBVideoItem{
+		ID:          info.Slug,
+		UUID:        info.ID,
+		Title:       info.Title,
+		Description: info.Description,
+		Type:        info.Type,
+		Date:        info.Date,
+		Site:        info.Site.Name,
+		Duration:    info.Duration,
+		Poster:      cmp.Or(info.PosterImage, info.Poster),
+		Cover:       info.Image,
+	}
+	for _, actor := range info.Performers {
+		item.Actors = append(item.Actors, actor.Name)
+		item.Performers = append(item.Performers, TPDBPerformerItem{
+			Name:    actor.Name,
+			Aliases: actor.Aliases,
+			Gender:  actor.Extras.Gender,
+		})
+	}
+	for _, director := range info.Directors {
+		item.Directors = append(item.Directors, director.Name)
+	}
+	for _, tag := range info.Tags {
+		item.Tags = append(item.Tags, tag.Name)
+	}
+	return item
+}
+
+// get fetches a ThePornDB API url and decodes its JSON response into out.
+func (s *ThePornDB) get(ctx context.Context, url_ string, query url.Values, out any) error {
 	u, err := url.Parse(url_)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	q := u.Query()
-	q.Set("q", query)
-	u.RawQuery = q.Encode()
+	u.RawQuery = query.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	req.Header.Add("Authorization", "Bearer "+s.apiToken)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
+	if err := checkProviderResponse("ThePornDB", "TPDB_API_TOKEN", resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s *ThePornDB) search(ctx context.Context, query url.Values, url_ string) ([]TPDBVideoItem, tpdbPageMeta, error) {
 	res := searchTPDBVideosResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&res)
-	if err != nil {
-		return nil, err
+	if err := s.get(ctx, url_, query, &res); err != nil {
+		return nil, tpdbPageMeta{}, err
 	}
 	var results []TPDBVideoItem
 	for _, item := range res.Data {
 		if len(results) >= tpdbLimitVideoPerType {
 			break
 		}
-
-		var actors []string
-		for _, actor := range item.Performers {
-			actors = append(actors, actor.Name)
-		}
-		var tags []string
-		for _, tag := range item.Tags {
-			tags = append(tags, tag.Name)
-		}
-		results = append(results, TPDBVideoItem{
-			ID:          item.Slug,
-			Title:       item.Title,
-			Description: item.Description,
-			Type:        item.Type,
-			Date:        item.Date,
-			Actors:      actors,
-			Tags:        tags,
-		})
+		results = append(results, newTPDBVideoItem(item))
 	}
-	return results, nil
+	return results, res.Meta, nil
 }
 
 // searchTPDBVideos will search both on scene and movie
 func (s *ThePornDB) searchTPDBVideos(ctx context.Context, input TPDBSearchVideosInput) (TPDBSearchVideosOutput, error) {
+	query, err := input.query()
+	if err != nil {
+		return TPDBSearchVideosOutput{}, err
+	}
 	// search scene
-	res, err := s.search(ctx, input.Query, tpdbSearchSceneURL)
+	res, sceneMeta, err := s.search(ctx, query, tpdbSearchSceneURL)
 	if err != nil {
 		return TPDBSearchVideosOutput{}, err
 	}
 	// search movie
-	searchMoviesRes, err := s.search(ctx, input.Query, tpdbSearchMovieURL)
+	searchMoviesRes, movieMeta, err := s.search(ctx, query, tpdbSearchMovieURL)
 	if err != nil {
 		return TPDBSearchVideosOutput{}, err
 	}
 	res = append(res, searchMoviesRes...)
-	return TPDBSearchVideosOutput{Results: res}, nil
+
+	page := max(input.Page, 1)
+	return TPDBSearchVideosOutput{
+		Results:     res,
+		SceneTotal:  sceneMeta.Total,
+		MovieTotal:  movieMeta.Total,
+		Page:        page,
+		HasNextPage: page < sceneMeta.LastPage || page < movieMeta.LastPage,
+	}, nil
 }
 
 func (s *ThePornDB) searchTPDBVideosTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBSearchVideosInput) (
 	*mcp.CallToolResult, TPDBSearchVideosOutput, error) {
-	result, err := s.searchTPDBVideos(ctx, input)
+	result, err := cachedCall(s.cache, "search_porn", input, "", input.Cache == cacheBypass,
+		func() (TPDBSearchVideosOutput, error) {
+			return s.searchTPDBVideos(ctx, input)
+		})
 	return nil, result, err
 }
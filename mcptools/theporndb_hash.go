@@ -0,0 +1,148 @@
+package mcptools
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	tpdbHashTypeOSHash = "oshash"
+	tpdbHashTypePHash  = "phash"
+
+	// osHashChunkSize is the size of the head and tail chunks summed by the OSHash.
+	osHashChunkSize = 64 * 1024
+)
+
+// tpdbHashPattern matches both OSHash and pHash, 64-bit values in hex.
+var tpdbHashPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// osHash computes the OpenSubtitles hash of a file: its size plus the sum of the little-endian
+// uint64 words of its first and last 64 KiB. Files smaller than that use chunks of their size
+// rounded down to whole words, the same way stash computes it.
+func osHash(r io.ReaderAt, size int64) (string, error) {
+	if size <= 8 {
+		return "", fmt.Errorf("file of %d bytes is too small to hash", size)
+	}
+	chunkSize := min(size, osHashChunkSize) / 8 * 8
+
+	hash := uint64(size)
+	buf := make([]byte, chunkSize)
+	for _, offset := range []int64{0, size - chunkSize} {
+		if _, err := r.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		for i := 0; i < len(buf); i += 8 {
+			hash += binary.LittleEndian.Uint64(buf[i:])
+		}
+	}
+	return fmt.Sprintf("%016x", hash), nil
+}
+
+// fileOSHash computes the OSHash of path, which must be inside mediaRoot.
+func fileOSHash(mediaRoot, path string) (string, error) {
+	if mediaRoot == "" {
+		return "", errors.New("path needs the media root to be configured")
+	}
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(mediaRoot, path)
+		if err != nil {
+			return "", fmt.Errorf("path %q is not inside the media root", path)
+		}
+		path = rel
+	}
+
+	// os.Root refuses paths escaping the media root, including through symlinks.
+	root, err := os.OpenRoot(mediaRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to open media root: %w", err)
+	}
+	defer root.Close()
+
+	f, err := root.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%q is not a regular file", path)
+	}
+	return osHash(f, info.Size())
+}
+
+type TPDBFindByHashInput struct {
+	Hash     string `json:"hash,omitempty" jsonschema:"the hash of the video file, 16 hex characters"`
+	HashType string `json:"hash_type,omitempty" jsonschema:"(optional) oshash (OpenSubtitles hash) or phash (perceptual hash), default is oshash"`
+	Path     string `json:"path,omitempty" jsonschema:"(optional) instead of hash, the path of a video file in the media root to compute the oshash of"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TPDBFindByHashOutput struct {
+	Hash     string          `json:"hash"`
+	HashType string          `json:"hash_type"`
+	Results  []TPDBVideoItem `json:"results"`
+}
+
+func (s *ThePornDB) findByHash(ctx context.Context, input TPDBFindByHashInput) (TPDBFindByHashOutput, error) {
+	hashType := strings.ToLower(cmp.Or(input.HashType, tpdbHashTypeOSHash))
+	if hashType != tpdbHashTypeOSHash && hashType != tpdbHashTypePHash {
+		return TPDBFindByHashOutput{}, fmt.Errorf("invalid hash_type %q, must be oshash or phash", input.HashType)
+	}
+
+	hash := strings.ToLower(strings.TrimSpace(input.Hash))
+	switch {
+	case hash != "" && input.Path != "":
+		return TPDBFindByHashOutput{}, errors.New("only one of hash and path can be set")
+	case input.Path != "":
+		if hashType != tpdbHashTypeOSHash {
+			return TPDBFindByHashOutput{}, errors.New("only the oshash can be computed from path")
+		}
+		var err error
+		if hash, err = fileOSHash(s.mediaRoot, input.Path); err != nil {
+			return TPDBFindByHashOutput{}, err
+		}
+	case !tpdbHashPattern.MatchString(hash):
+		return TPDBFindByHashOutput{}, fmt.Errorf("invalid hash %q, must be 16 hex characters", input.Hash)
+	}
+
+	res := searchTPDBVideosResponse{}
+	query := url.Values{"hash": {hash}, "hash_type": {strings.ToUpper(hashType)}}
+	if err := s.get(ctx, tpdbSearchSceneURL, query, &res); err != nil {
+		return TPDBFindByHashOutput{}, err
+	}
+
+	output := TPDBFindByHashOutput{Hash: hash, HashType: hashType, Results: []TPDBVideoItem{}}
+	for _, info := range res.Data {
+		output.Results = append(output.Results, newTPDBVideoItem(info))
+	}
+	return output, nil
+}
+
+func (s *ThePornDB) findByHashTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBFindByHashInput) (
+	*mcp.CallToolResult, TPDBFindByHashOutput, error) {
+	// Files can change under the same path, only lookups by hash are cached.
+	if input.Path != "" {
+		result, err := s.findByHash(ctx, input)
+		return nil, result, err
+	}
+	result, err := cachedCall(s.cache, "find_porn_by_hash", input, "", input.Cache == cacheBypass,
+		func() (TPDBFindByHashOutput, error) {
+			return s.findByHash(ctx, input)
+		})
+	return nil, result, err
+}
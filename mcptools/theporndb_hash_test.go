@@ -0,0 +1,194 @@
+package mcptools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// osHashTestFile returns size bytes of zeros with 1 as the first word and 2 as the last one.
+func osHashTestFile(size int) []byte {
+	data := make([]byte, size)
+	binary.LittleEndian.PutUint64(data, 1)
+	binary.LittleEndian.PutUint64(data[size-8:], 2)
+	return data
+}
+
+func TestOSHash(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want string
+	}{
+		{
+			name: "large file",
+			size: 200000,
+			want: "0000000000030d43", // 200000 + 1 + 2
+		},
+		{
+			name: "file smaller than a chunk",
+			size: 16,
+			want: "0000000000000016", // 16 + (1 + 2) * 2, both chunks are the whole file
+		},
+		{
+			// Values from stash's oshash package.
+			name: "file smaller than a chunk with a partial word",
+			size: 20,
+			want: "0000000200000017", // chunks are the first and last 16 bytes
+		},
+		{
+			name: "file larger than a chunk with a partial word",
+			size: 100003,
+			want: "00000000000186a6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := osHash(bytes.NewReader(osHashTestFile(tt.size)), int64(tt.size))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := osHash(bytes.NewReader(make([]byte, 8)), 8)
+	require.ErrorContains(t, err, "file of 8 bytes is too small to hash")
+}
+
+func TestFindTPDBByHashRelativeMediaRoot(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0000000000030d43", r.URL.Query().Get("hash"))
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "media"), 0o755))
+	path := filepath.Join(dir, "media", "a1b2c3.mp4")
+	require.NoError(t, os.WriteFile(path, osHashTestFile(200000), 0o644))
+	t.Chdir(dir)
+	tpdb := NewThePornDB("token", "media", client, nil)
+
+	got, err := tpdb.findByHash(t.Context(), TPDBFindByHashInput{Path: path})
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000030d43", got.Hash)
+}
+
+func TestFindTPDBByHashLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/scenes", r.URL.Path)
+		assert.Equal(t, "OSHASH", r.URL.Query().Get("hash_type"))
+		if r.URL.Query().Get("hash") != "0000000000030d43" {
+			_, _ = w.Write([]byte(`{"data": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{"slug": "brazzers-long-con", "title": "Long Con", "site": {"name": "Brazzers"}}]}`))
+	}))
+
+	mediaRoot := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(mediaRoot, "scenes"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(mediaRoot, "scenes", "a1b2c3.mp4"), osHashTestFile(200000), 0o644))
+	tpdb := NewThePornDB("token", mediaRoot, client, nil)
+
+	for _, input := range []TPDBFindByHashInput{
+		{Hash: "0000000000030D43"},
+		{Path: "scenes/a1b2c3.mp4"},
+		{Path: filepath.Join(mediaRoot, "scenes", "a1b2c3.mp4")},
+	} {
+		got, err := tpdb.findByHash(t.Context(), input)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000030d43", got.Hash)
+		assert.Equal(t, "oshash", got.HashType)
+		require.Len(t, got.Results, 1)
+		assert.Equal(t, "brazzers-long-con", got.Results[0].ID)
+		assert.Equal(t, "Brazzers", got.Results[0].Site)
+	}
+
+	got, err := tpdb.findByHash(t.Context(), TPDBFindByHashInput{Hash: "ffffffffffffffff"})
+	require.NoError(t, err)
+	assert.Empty(t, got.Results)
+}
+
+func TestFindTPDBByHashInvalidInput(t *testing.T) {
+	mediaRoot := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "outside.mp4")
+	require.NoError(t, os.WriteFile(outside, osHashTestFile(16), 0o644))
+	require.NoError(t, os.Symlink(outside, filepath.Join(mediaRoot, "link.mp4")))
+
+	tests := []struct {
+		name      string
+		mediaRoot string
+		input     TPDBFindByHashInput
+		wantErr   string
+	}{
+		{
+			name:    "missing hash",
+			input:   TPDBFindByHashInput{},
+			wantErr: `invalid hash ""`,
+		},
+		{
+			name:    "malformed hash",
+			input:   TPDBFindByHashInput{Hash: "not-a-hash"},
+			wantErr: `invalid hash "not-a-hash", must be 16 hex characters`,
+		},
+		{
+			name:    "invalid hash type",
+			input:   TPDBFindByHashInput{Hash: "0000000000030d43", HashType: "md5"},
+			wantErr: `invalid hash_type "md5"`,
+		},
+		{
+			name:      "hash and path",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Hash: "0000000000030d43", Path: "video.mp4"},
+			wantErr:   "only one of hash and path can be set",
+		},
+		{
+			name:      "phash from path",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Path: "video.mp4", HashType: "phash"},
+			wantErr:   "only the oshash can be computed from path",
+		},
+		{
+			name:    "path without media root",
+			input:   TPDBFindByHashInput{Path: "video.mp4"},
+			wantErr: "path needs the media root to be configured",
+		},
+		{
+			name:      "relative path escaping media root",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Path: "../outside.mp4"},
+			wantErr:   "failed to open file",
+		},
+		{
+			name:      "absolute path outside media root",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Path: outside},
+			wantErr:   "failed to open file",
+		},
+		{
+			name:      "symlink escaping media root",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Path: "link.mp4"},
+			wantErr:   "failed to open file",
+		},
+		{
+			name:      "directory",
+			mediaRoot: mediaRoot,
+			input:     TPDBFindByHashInput{Path: "."},
+			wantErr:   "is not a regular file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpdb := NewThePornDB("token", tt.mediaRoot, nil, nil)
+			_, err := tpdb.findByHash(t.Context(), tt.input)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package mcptools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	// releaseSeparatorPattern splits release names like "Site.24.03.15.Jane.Doe.Title.1080p".
+	releaseSeparatorPattern = regexp.MustCompile(`[\s._\-]+`)
+	// releaseBracketPattern matches bracketed groups, e.g. "[1080p]" or "(2024)".
+	releaseBracketPattern = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+)
+
+// releaseNoiseTokens are quality, codec and release group tokens carrying no metadata.
+var releaseNoiseTokens = map[string]bool{
+	"xxx": true, "480p": true, "540p": true, "720p": true, "1080p": true, "2160p": true, "4k": true, "uhd": true,
+	"hd": true, "sd": true, "web": true, "webrip": true, "webdl": true, "dl": true, "mp4": true, "mkv": true,
+	"h264": true, "x264": true, "h265": true, "x265": true, "hevc": true, "avc": true, "aac": true, "ktr": true,
+	"vsex": true, "xc": true, "p2p": true,
+}
+
+// releaseVideoExtensions are dropped from filenames before parsing.
+var releaseVideoExtensions = []string{".mp4", ".mkv", ".avi", ".wmv", ".mov", ".m4v", ".flv", ".webm", ".ts", ".mpg", ".mpeg"}
+
+// parsedReleaseName is what can be told from a release name without looking it up.
+type parsedReleaseName struct {
+	// Name is the filename without extension, as sent to ThePornDB.
+	Name string
+	Site string
+	Date string
+	// Words after the date, or all words without a date: the performers and the title.
+	Words []string
+}
+
+// normalizedWords returns the words normalized by normalizeReleaseWord.
+func (p parsedReleaseName) normalizedWords() []string {
+	var words []string
+	for _, word := range p.Words {
+		words = append(words, normalizeReleaseWord(word))
+	}
+	return words
+}
+
+// releaseDate returns the date of three consecutive tokens like "24 03 15" or "2024 03 15".
+func releaseDate(year, month, day string) (string, bool) {
+	if len(month) != 2 || len(day) != 2 {
+		return "", false
+	}
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return "", false
+	}
+	switch len(year) {
+	case 2:
+		// Two-digit years are in the past, "99" is 1999 and "24" is 2024.
+		if y += 2000; y > time.Now().Year() {
+			y -= 100
+		}
+	case 4:
+	default:
+		return "", false
+	}
+	date := fmt.Sprintf("%04d-%s-%s", y, month, day)
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return "", false
+	}
+	return date, true
+}
+
+// parseReleaseName splits a release name like "Site.24.03.15.Jane.Doe.Title.1080p.mp4" into its
+// site, date and remaining words.
+func parseReleaseName(filename string) parsedReleaseName {
+	name := path.Base(strings.ReplaceAll(strings.TrimSpace(filename), `\`, "/"))
+	if ext := strings.ToLower(path.Ext(name)); slices.Contains(releaseVideoExtensions, ext) {
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	parsed := parsedReleaseName{Name: name}
+	var tokens []string
+	for _, token := range releaseSeparatorPattern.Split(releaseBracketPattern.ReplaceAllString(name, " "), -1) {
+		if token != "" && !releaseNoiseTokens[strings.ToLower(token)] {
+			tokens = append(tokens, token)
+		}
+	}
+
+	for i := 0; i+2 < len(tokens); i++ {
+		if date, ok := releaseDate(tokens[i], tokens[i+1], tokens[i+2]); ok {
+			parsed.Site = strings.Join(tokens[:i], " ")
+			parsed.Date = date
+			parsed.Words = tokens[i+3:]
+			return parsed
+		}
+	}
+	parsed.Words = tokens
+	return parsed
+}
+
+// normalizeReleaseWord lowercases s and keeps only its letters and digits, so "Brazzers Exxtra"
+// matches "BrazzersExxtra".
+func normalizeReleaseWord(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// wordsContain reports whether all words of name appear in words.
+func wordsContain(words []string, name string) bool {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, field := range fields {
+		if !slices.Contains(words, normalizeReleaseWord(field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Weights of the parts of a release name in the confidence of a match.
+const (
+	releaseSiteWeight      = 0.3
+	releaseDateWeight      = 0.3
+	releasePerformerWeight = 0.2
+	releaseTitleWeight     = 0.2
+)
+
+// matchConfidence scores how well a scene matches a parsed release name, from 0 to 1. Parts the
+// release name or the scene lack are left out rather than counted as mismatches.
+func matchConfidence(parsed parsedReleaseName, item TPDBVideoItem) float64 {
+	words := parsed.normalizedWords()
+
+	var score, total float64
+	if parsed.Site != "" && item.Site != "" {
+		total += releaseSiteWeight
+		site, itemSite := normalizeReleaseWord(parsed.Site), normalizeReleaseWord(item.Site)
+		if site == itemSite || strings.Contains(itemSite, site) || strings.Contains(site, itemSite) {
+			score += releaseSiteWeight
+		}
+	}
+	if parsed.Date != "" && item.Date != "" {
+		total += releaseDateWeight
+		if parsed.Date == item.Date {
+			score += releaseDateWeight
+		}
+	}
+	if len(words) > 0 && len(item.Actors) > 0 {
+		total += releasePerformerWeight
+		matched := 0
+		for _, actor := range item.Actors {
+			if wordsContain(words, actor) {
+				matched++
+			}
+		}
+		score += releasePerformerWeight * float64(matched) / float64(len(item.Actors))
+	}
+	if titleWords := strings.Fields(item.Title); len(words) > 0 && len(titleWords) > 0 {
+		total += releaseTitleWeight
+		matched := 0
+		for _, word := range titleWords {
+			if slices.Contains(words, normalizeReleaseWord(word)) {
+				matched++
+			}
+		}
+		score += releaseTitleWeight * float64(matched) / float64(len(titleWords))
+	}
+	if total == 0 {
+		return 0
+	}
+	return math.Round(score/total*100) / 100
+}
+
+type TPDBParseFilenameInput struct {
+	Filename string `json:"filename" jsonschema:"the raw file or release name, e.g. Brazzers.24.03.15.Jane.Doe.Long.Con.1080p.mp4"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TPDBFilenameMatch struct {
+	Scene      TPDBVideoItem `json:"scene"`
+	Confidence float64       `json:"confidence" jsonschema:"how well the scene matches the parsed site, date, performers and title, from 0 to 1"`
+}
+
+type TPDBParseFilenameOutput struct {
+	Site       string              `json:"site,omitempty" jsonschema:"the site parsed from the filename"`
+	Date       string              `json:"date,omitempty" jsonschema:"the release date parsed from the filename, as YYYY-MM-DD"`
+	Performers []string            `json:"performers,omitempty" jsonschema:"the performers of the best match found in the filename"`
+	Title      string              `json:"title,omitempty" jsonschema:"the rest of the filename after the site, date and performers"`
+	Matches    []TPDBFilenameMatch `json:"matches" jsonschema:"scenes matching the filename, best first"`
+}
+
+func (s *ThePornDB) parseFilename(ctx context.Context, input TPDBParseFilenameInput) (TPDBParseFilenameOutput, error) {
+	parsed := parseReleaseName(input.Filename)
+	if parsed.Name == "" || parsed.Name == "." || parsed.Name == "/" {
+		return TPDBParseFilenameOutput{}, errors.New("filename is required")
+	}
+
+	res := searchTPDBVideosResponse{}
+	if err := s.get(ctx, tpdbSearchSceneURL, url.Values{"parse": {parsed.Name}}, &res); err != nil {
+		return TPDBParseFilenameOutput{}, err
+	}
+
+	output := TPDBParseFilenameOutput{Site: parsed.Site, Date: parsed.Date, Matches: []TPDBFilenameMatch{}}
+	for _, info := range res.Data {
+		item := newTPDBVideoItem(info)
+		output.Matches = append(output.Matches, TPDBFilenameMatch{Scene: item, Confidence: matchConfidence(parsed, item)})
+	}
+	// Stable, so ThePornDB's order breaks ties.
+	slices.SortStableFunc(output.Matches, func(a, b TPDBFilenameMatch) int {
+		return cmp.Compare(b.Confidence, a.Confidence)
+	})
+	if len(output.Matches) > tpdbLimitVideoPerType {
+		output.Matches = output.Matches[:tpdbLimitVideoPerType]
+	}
+
+	// Tell performers from the title with the performers of the best match.
+	words := parsed.normalizedWords()
+	performerWords := map[string]bool{}
+	if len(output.Matches) > 0 {
+		for _, actor := range output.Matches[0].Scene.Actors {
+			if wordsContain(words, actor) {
+				output.Performers = append(output.Performers, actor)
+				for _, field := range strings.Fields(actor) {
+					performerWords[normalizeReleaseWord(field)] = true
+				}
+			}
+		}
+	}
+	var title []string
+	for _, word := range parsed.Words {
+		if !performerWords[normalizeReleaseWord(word)] {
+			title = append(title, word)
+		}
+	}
+	output.Title = strings.Join(title, " ")
+	return output, nil
+}
+
+func (s *ThePornDB) parseFilenameTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBParseFilenameInput) (
+	*mcp.CallToolResult, TPDBParseFilenameOutput, error) {
+	result, err := cachedCall(s.cache, "parse_porn_filename", input, "", input.Cache == cacheBypass,
+		func() (TPDBParseFilenameOutput, error) {
+			return s.parseFilename(ctx, input)
+		})
+	return nil, result, err
+}
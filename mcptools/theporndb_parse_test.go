@@ -0,0 +1,93 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReleaseName(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     parsedReleaseName
+	}{
+		{
+			filename: "Brazzers.24.03.15.Jane.Doe.Long.Con.1080p.mp4",
+			want: parsedReleaseName{
+				Name:  "Brazzers.24.03.15.Jane.Doe.Long.Con.1080p",
+				Site:  "Brazzers",
+				Date:  "2024-03-15",
+				Words: []string{"Jane", "Doe", "Long", "Con"},
+			},
+		},
+		{
+			filename: `D:\Videos\Brazzers Exxtra - 2023-12-01 - Jane Doe [2160p].mkv`,
+			want: parsedReleaseName{
+				Name:  "Brazzers Exxtra - 2023-12-01 - Jane Doe [2160p]",
+				Site:  "Brazzers Exxtra",
+				Date:  "2023-12-01",
+				Words: []string{"Jane", "Doe"},
+			},
+		},
+		{
+			filename: "/media/site.99.12.31.title.XXX.720p.WEB.x264",
+			want: parsedReleaseName{
+				Name:  "site.99.12.31.title.XXX.720p.WEB.x264",
+				Site:  "site",
+				Date:  "1999-12-31",
+				Words: []string{"title"},
+			},
+		},
+		{
+			filename: "jane_doe_long_con.24.13.40.avi",
+			want: parsedReleaseName{
+				Name:  "jane_doe_long_con.24.13.40",
+				Words: []string{"jane", "doe", "long", "con", "24", "13", "40"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseReleaseName(tt.filename))
+		})
+	}
+}
+
+func TestParseTPDBFilenameLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/scenes", r.URL.Path)
+		assert.Equal(t, "Brazzers.24.03.15.Jane.Doe.Long.Con.1080p", r.URL.Query().Get("parse"))
+		_, _ = w.Write([]byte(`{"data": [{
+			"slug": "realitykings-long-con",
+			"title": "The Long Con",
+			"date": "2022-06-01",
+			"site": {"name": "Reality Kings"},
+			"performers": [{"name": "Janet Doe"}]
+		}, {
+			"slug": "brazzers-long-con",
+			"title": "Long Con",
+			"date": "2024-03-15",
+			"site": {"name": "Brazzers"},
+			"performers": [{"name": "Jane Doe"}, {"name": "John Roe"}]
+		}]}`))
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.parseFilename(t.Context(), TPDBParseFilenameInput{Filename: "Brazzers.24.03.15.Jane.Doe.Long.Con.1080p.mp4"})
+	require.NoError(t, err)
+	assert.Equal(t, "Brazzers", got.Site)
+	assert.Equal(t, "2024-03-15", got.Date)
+	assert.Equal(t, []string{"Jane Doe"}, got.Performers)
+	assert.Equal(t, "Long Con", got.Title)
+	require.Len(t, got.Matches, 2)
+	assert.Equal(t, "brazzers-long-con", got.Matches[0].Scene.ID)
+	assert.Equal(t, 0.9, got.Matches[0].Confidence) // one of two performers
+	assert.Equal(t, "realitykings-long-con", got.Matches[1].Scene.ID)
+	assert.Equal(t, 0.13, got.Matches[1].Confidence) // two of three title words
+
+	_, err = tpdb.parseFilename(t.Context(), TPDBParseFilenameInput{Filename: " "})
+	require.ErrorContains(t, err, "filename is required")
+}
@@ -0,0 +1,130 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	tpdbLimitPerformerCount = 10
+	tpdbPerformersURL       = "https://api.theporndb.net/performers"
+)
+
+type TPDBPerformer struct {
+	ID              string   `json:"id" jsonschema:"the slug of the performer"`
+	UUID            string   `json:"uuid,omitempty" jsonschema:"the ThePornDB uuid of the performer"`
+	Name            string   `json:"name"`
+	Aliases         []string `json:"aliases,omitempty" jsonschema:"other names the performer is credited as"`
+	Bio             string   `json:"bio,omitempty"`
+	Gender          string   `json:"gender,omitempty"`
+	Birthday        string   `json:"birthday,omitempty"`
+	Birthplace      string   `json:"birthplace,omitempty"`
+	Nationality     string   `json:"nationality,omitempty"`
+	Ethnicity       string   `json:"ethnicity,omitempty"`
+	HairColour      string   `json:"hair_colour,omitempty"`
+	EyeColour       string   `json:"eye_colour,omitempty"`
+	Height          string   `json:"height,omitempty"`
+	Weight          string   `json:"weight,omitempty"`
+	Measurements    string   `json:"measurements,omitempty"`
+	Cupsize         string   `json:"cupsize,omitempty"`
+	Tattoos         string   `json:"tattoos,omitempty"`
+	Piercings       string   `json:"piercings,omitempty"`
+	CareerStartYear int      `json:"career_start_year,omitempty"`
+	CareerEndYear   int      `json:"career_end_year,omitempty"`
+	Image           string   `json:"image,omitempty" jsonschema:"the url of the performer's image"`
+}
+
+func newTPDBPerformer(info tpdbActorInfo) TPDBPerformer {
+	return TPDBPerformer{
+		ID:              info.Slug,
+		UUID:            info.ID,
+		Name:            info.Name,
+		Aliases:         info.Aliases,
+		Bio:             info.Bio,
+		Gender:          info.Extras.Gender,
+		Birthday:        info.Extras.Birthday,
+		Birthplace:      info.Extras.Birthplace,
+		Nationality:     info.Extras.Nationality,
+		Ethnicity:       info.Extras.Ethnicity,
+		HairColour:      info.Extras.HairColour,
+		EyeColour:       info.Extras.EyeColour,
+		Height:          info.Extras.Height,
+		Weight:          info.Extras.Weight,
+		Measurements:    info.Extras.Measurements,
+		Cupsize:         info.Extras.Cupsize,
+		Tattoos:         info.Extras.Tattoos,
+		Piercings:       info.Extras.Piercings,
+		CareerStartYear: info.Extras.CareerStartYear,
+		CareerEndYear:   info.Extras.CareerEndYear,
+		Image:           info.Image,
+	}
+}
+
+type TPDBSearchPerformersInput struct {
+	Name  string `json:"name" jsonschema:"the name of the performer to search for"`
+	Cache string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TPDBSearchPerformersOutput struct {
+	Results []TPDBPerformer `json:"results"`
+}
+
+func (s *ThePornDB) searchPerformers(ctx context.Context, input TPDBSearchPerformersInput) (TPDBSearchPerformersOutput, error) {
+	res := struct {
+		Data []tpdbActorInfo `json:"data"`
+	}{}
+	if err := s.get(ctx, tpdbPerformersURL, url.Values{"q": {input.Name}}, &res); err != nil {
+		return TPDBSearchPerformersOutput{}, err
+	}
+
+	output := TPDBSearchPerformersOutput{Results: []TPDBPerformer{}}
+	for _, info := range res.Data {
+		if len(output.Results) >= tpdbLimitPerformerCount {
+			break
+		}
+		output.Results = append(output.Results, newTPDBPerformer(info))
+	}
+	return output, nil
+}
+
+func (s *ThePornDB) searchPerformersTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBSearchPerformersInput) (
+	*mcp.CallToolResult, TPDBSearchPerformersOutput, error) {
+	result, err := cachedCall(s.cache, "search_porn_performers", input, "", input.Cache == cacheBypass,
+		func() (TPDBSearchPerformersOutput, error) {
+			return s.searchPerformers(ctx, input)
+		})
+	return nil, result, err
+}
+
+type TPDBGetPerformerInput struct {
+	ID    string `json:"id" jsonschema:"the slug, uuid or numeric id of the performer"`
+	Cache string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+func (s *ThePornDB) getPerformer(ctx context.Context, input TPDBGetPerformerInput) (TPDBPerformer, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return TPDBPerformer{}, errors.New("id is required")
+	}
+
+	res := struct {
+		Data tpdbActorInfo `json:"data"`
+	}{}
+	if err := s.get(ctx, tpdbPerformersURL+"/"+url.PathEscape(id), nil, &res); err != nil {
+		return TPDBPerformer{}, err
+	}
+	return newTPDBPerformer(res.Data), nil
+}
+
+func (s *ThePornDB) getPerformerTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBGetPerformerInput) (
+	*mcp.CallToolResult, TPDBPerformer, error) {
+	result, err := cachedCall(s.cache, "get_porn_performer", input, "", input.Cache == cacheBypass,
+		func() (TPDBPerformer, error) {
+			return s.getPerformer(ctx, input)
+		})
+	return nil, result, err
+}
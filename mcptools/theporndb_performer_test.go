@@ -0,0 +1,73 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tpdbPerformerTestJSON = `{
+	"id": "9b1f4a2e-7c3d-4e5f-8a9b-0c1d2e3f4a5b",
+	"slug": "jane-doe",
+	"name": "Jane Doe",
+	"bio": "Jane Doe is a performer.",
+	"aliases": ["Janie D", "J. Doe"],
+	"image": "https://cdn.theporndb.net/performer/jane.jpg",
+	"extras": {
+		"gender": "Female",
+		"birthday": "1995-04-01",
+		"birthplace": "Los Angeles",
+		"nationality": "United States",
+		"measurements": "34B-24-34",
+		"cupsize": "B",
+		"career_start_year": 2015,
+		"career_end_year": 2022
+	}
+}`
+
+var tpdbPerformerTestWant = TPDBPerformer{
+	ID:              "jane-doe",
+	UUID:            "9b1f4a2e-7c3d-4e5f-8a9b-0c1d2e3f4a5b",
+	Name:            "Jane Doe",
+	Aliases:         []string{"Janie D", "J. Doe"},
+	Bio:             "Jane Doe is a performer.",
+	Gender:          "Female",
+	Birthday:        "1995-04-01",
+	Birthplace:      "Los Angeles",
+	Nationality:     "United States",
+	Measurements:    "34B-24-34",
+	Cupsize:         "B",
+	CareerStartYear: 2015,
+	CareerEndYear:   2022,
+	Image:           "https://cdn.theporndb.net/performer/jane.jpg",
+}
+
+func TestSearchTPDBPerformersLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/performers", r.URL.Path)
+		assert.Equal(t, "jane doe", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte(`{"data": [` + tpdbPerformerTestJSON + `]}`))
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.searchPerformers(t.Context(), TPDBSearchPerformersInput{Name: "jane doe"})
+	require.NoError(t, err)
+	assert.Equal(t, []TPDBPerformer{tpdbPerformerTestWant}, got.Results)
+}
+
+func TestGetTPDBPerformerLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/performers/jane-doe", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data": ` + tpdbPerformerTestJSON + `}`))
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.getPerformer(t.Context(), TPDBGetPerformerInput{ID: "jane-doe"})
+	require.NoError(t, err)
+	assert.Equal(t, tpdbPerformerTestWant, got)
+
+	_, err = tpdb.getPerformer(t.Context(), TPDBGetPerformerInput{ID: " "})
+	require.ErrorContains(t, err, "id is required")
+}
@@ -0,0 +1,103 @@
+package mcptools
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	tpdbLimitSiteCount = 10
+	tpdbSitesURL       = "https://api.theporndb.net/sites"
+)
+
+type tpdbSiteInfo struct {
+	UUID        string `json:"uuid"`
+	ID          int    `json:"id"`
+	ParentID    int    `json:"parent_id"`
+	NetworkID   int    `json:"network_id"`
+	Name        string `json:"name"`
+	ShortName   string `json:"short_name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Logo        string `json:"logo"`
+
+	Parent  *tpdbSiteInfo `json:"parent"`
+	Network *tpdbSiteInfo `json:"network"`
+}
+
+// TPDBSiteRef is a parent site or network of a site.
+type TPDBSiteRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type TPDBSite struct {
+	ID          int          `json:"id"`
+	UUID        string       `json:"uuid,omitempty"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"short_name,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Logo        string       `json:"logo,omitempty"`
+	Parent      *TPDBSiteRef `json:"parent,omitempty" jsonschema:"the site this one is part of, if any"`
+	Network     *TPDBSiteRef `json:"network,omitempty" jsonschema:"the network owning the site, if any"`
+}
+
+func newTPDBSiteRef(info *tpdbSiteInfo) *TPDBSiteRef {
+	if info == nil || info.Name == "" {
+		return nil
+	}
+	return &TPDBSiteRef{ID: info.ID, Name: info.Name}
+}
+
+func newTPDBSite(info tpdbSiteInfo) TPDBSite {
+	return TPDBSite{
+		ID:          info.ID,
+		UUID:        info.UUID,
+		Name:        info.Name,
+		ShortName:   info.ShortName,
+		URL:         info.URL,
+		Description: info.Description,
+		Logo:        info.Logo,
+		Parent:      newTPDBSiteRef(info.Parent),
+		Network:     newTPDBSiteRef(info.Network),
+	}
+}
+
+type TPDBSearchSitesInput struct {
+	Name  string `json:"name" jsonschema:"the name of the site or network to search for"`
+	Cache string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TPDBSearchSitesOutput struct {
+	Results []TPDBSite `json:"results"`
+}
+
+func (s *ThePornDB) searchSites(ctx context.Context, input TPDBSearchSitesInput) (TPDBSearchSitesOutput, error) {
+	res := struct {
+		Data []tpdbSiteInfo `json:"data"`
+	}{}
+	if err := s.get(ctx, tpdbSitesURL, url.Values{"q": {input.Name}}, &res); err != nil {
+		return TPDBSearchSitesOutput{}, err
+	}
+
+	output := TPDBSearchSitesOutput{Results: []TPDBSite{}}
+	for _, info := range res.Data {
+		if len(output.Results) >= tpdbLimitSiteCount {
+			break
+		}
+		output.Results = append(output.Results, newTPDBSite(info))
+	}
+	return output, nil
+}
+
+func (s *ThePornDB) searchSitesTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBSearchSitesInput) (
+	*mcp.CallToolResult, TPDBSearchSitesOutput, error) {
+	result, err := cachedCall(s.cache, "search_porn_sites", input, "", input.Cache == cacheBypass,
+		func() (TPDBSearchSitesOutput, error) {
+			return s.searchSites(ctx, input)
+		})
+	return nil, result, err
+}
@@ -0,0 +1,46 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTPDBSitesLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sites", r.URL.Path)
+		assert.Equal(t, "brazzers exxtra", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte(`{"data": [{
+			"uuid": "1a2b3c4d-0000-0000-0000-000000000001",
+			"id": 42,
+			"name": "Brazzers Exxtra",
+			"short_name": "brazzersexxtra",
+			"url": "https://www.brazzers.com",
+			"parent": {"id": 7, "name": "Brazzers"},
+			"network": {"id": 1, "name": "Brazzers"}
+		}, {
+			"id": 7,
+			"name": "Brazzers",
+			"parent": null,
+			"network": null
+		}]}`))
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.searchSites(t.Context(), TPDBSearchSitesInput{Name: "brazzers exxtra"})
+	require.NoError(t, err)
+	assert.Equal(t, []TPDBSite{
+		{
+			ID:        42,
+			UUID:      "1a2b3c4d-0000-0000-0000-000000000001",
+			Name:      "Brazzers Exxtra",
+			ShortName: "brazzersexxtra",
+			URL:       "https://www.brazzers.com",
+			Parent:    &TPDBSiteRef{ID: 7, Name: "Brazzers"},
+			Network:   &TPDBSiteRef{ID: 1, Name: "Brazzers"},
+		},
+		{ID: 7, Name: "Brazzers"},
+	}, got.Results)
+}
@@ -1,9 +1,11 @@
 package mcptools
 
 import (
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,8 +20,129 @@ func tpdbTokenFromEnv(t *testing.T) string {
 
 func TestSearchTPDBVideo(t *testing.T) {
 	token := tpdbTokenFromEnv(t)
-	tpdb := NewThePornDB(token)
+	tpdb := NewThePornDB(token, "", nil, nil)
 	got, err := tpdb.searchTPDBVideos(t.Context(), TPDBSearchVideosInput{Query: "Long Con"})
 	require.NoError(t, err)
 	require.NotEmpty(t, got.Results)
 }
+
+func TestSearchTPDBVideoLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "long con", r.URL.Query().Get("q"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/scenes":
+			_, _ = w.Write([]byte(`{"data": [{
+				"id": "5f1b7c1e-1b2a-4c3d-9e8f-0a1b2c3d4e5f",
+				"slug": "brazzers-long-con",
+				"title": "Long Con",
+				"type": "Scene",
+				"date": "2024-03-15",
+				"duration": 2460,
+				"image": "https://cdn.theporndb.net/scene/cover.jpg",
+				"poster": "https://cdn.theporndb.net/scene/poster.jpg",
+				"site": {"name": "Brazzers"},
+				"directors": [{"name": "Director One"}],
+				"performers": [{"name": "Jane Doe", "aliases": ["Janie D"], "extras": {"gender": "Female"}}],
+				"tags": [{"name": "Heist"}]
+			}]}`))
+		case "/movies":
+			_, _ = w.Write([]byte(`{"data": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.searchTPDBVideos(t.Context(), TPDBSearchVideosInput{Query: "long con"})
+	require.NoError(t, err)
+	assert.Equal(t, []TPDBVideoItem{{
+		ID:         "brazzers-long-con",
+		UUID:       "5f1b7c1e-1b2a-4c3d-9e8f-0a1b2c3d4e5f",
+		Title:      "Long Con",
+		Type:       "Scene",
+		Date:       "2024-03-15",
+		Site:       "Brazzers",
+		Duration:   2460,
+		Actors:     []string{"Jane Doe"},
+		Performers: []TPDBPerformerItem{{Name: "Jane Doe", Aliases: []string{"Janie D"}, Gender: "Female"}},
+		Directors:  []string{"Director One"},
+		Tags:       []string{"Heist"},
+		Poster:     "https://cdn.theporndb.net/scene/poster.jpg",
+		Cover:      "https://cdn.theporndb.net/scene/cover.jpg",
+	}}, got.Results)
+}
+
+func TestSearchTPDBVideoFiltersLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Empty(t, q.Get("q"))
+		assert.Equal(t, "brazzers", q.Get("site"))
+		assert.Equal(t, "2024-03-01", q.Get("date_from"))
+		assert.Equal(t, "2024-03-31", q.Get("date_to"))
+		assert.Equal(t, "Jane Doe", q.Get("performers"))
+		assert.Equal(t, "2", q.Get("page"))
+		assert.Equal(t, "10", q.Get("per_page"))
+		switch r.URL.Path {
+		case "/scenes":
+			_, _ = w.Write([]byte(`{"data": [{"slug": "brazzers-long-con", "title": "Long Con"}],
+				"meta": {"current_page": 2, "last_page": 3, "per_page": 10, "total": 25}}`))
+		case "/movies":
+			_, _ = w.Write([]byte(`{"data": [], "meta": {"current_page": 2, "last_page": 1, "per_page": 10, "total": 0}}`))
+		}
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	got, err := tpdb.searchTPDBVideos(t.Context(), TPDBSearchVideosInput{
+		Site:      "brazzers",
+		DateFrom:  "2024-03-01",
+		DateTo:    "2024-03-31",
+		Performer: "Jane Doe",
+		Page:      2,
+	})
+	require.NoError(t, err)
+	require.Len(t, got.Results, 1)
+	assert.Equal(t, "brazzers-long-con", got.Results[0].ID)
+	assert.Equal(t, 25, got.SceneTotal)
+	assert.Equal(t, 0, got.MovieTotal)
+	assert.Equal(t, 2, got.Page)
+	assert.True(t, got.HasNextPage)
+}
+
+func TestSearchTPDBVideoInvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   TPDBSearchVideosInput
+		wantErr string
+	}{
+		{
+			name:    "no filter",
+			input:   TPDBSearchVideosInput{Page: 2},
+			wantErr: "one of query, site, date, date_from, date_to or performer is required",
+		},
+		{
+			name:    "invalid date",
+			input:   TPDBSearchVideosInput{Site: "brazzers", Date: "24.03.15"},
+			wantErr: `invalid date "24.03.15", must be YYYY-MM-DD`,
+		},
+		{
+			name:    "invalid date_to",
+			input:   TPDBSearchVideosInput{Site: "brazzers", DateTo: "2024-13-01"},
+			wantErr: `invalid date_to "2024-13-01"`,
+		},
+		{
+			name:    "negative page",
+			input:   TPDBSearchVideosInput{Query: "long con", Page: -1},
+			wantErr: "invalid page -1",
+		},
+	}
+
+	tpdb := NewThePornDB("token", "", nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tpdb.searchTPDBVideos(t.Context(), tt.input)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TPDBMarker is a chapter of a video.
+type TPDBMarker struct {
+	Title     string `json:"title"`
+	StartTime int    `json:"start_time" jsonschema:"the start of the chapter in seconds"`
+	EndTime   int    `json:"end_time,omitempty" jsonschema:"the end of the chapter in seconds"`
+}
+
+type TPDBHash struct {
+	Hash     string `json:"hash"`
+	Type     string `json:"type" jsonschema:"OSHASH, PHASH or MD5"`
+	Duration int    `json:"duration,omitempty" jsonschema:"the duration of the hashed file in seconds"`
+}
+
+// TPDBVideoRef is a movie a scene is part of, or a scene of a movie.
+type TPDBVideoRef struct {
+	ID    string `json:"id" jsonschema:"the slug of the video"`
+	Title string `json:"title"`
+	Type  string `json:"type" jsonschema:"scene or movie"`
+	Date  string `json:"date,omitempty"`
+	Site  string `json:"site,omitempty"`
+}
+
+type TPDBVideoDetails struct {
+	TPDBVideoItem
+	URL     string         `json:"url,omitempty" jsonschema:"the page of the video on its site"`
+	Trailer string         `json:"trailer,omitempty" jsonschema:"the url of the trailer"`
+	Markers []TPDBMarker   `json:"markers,omitempty" jsonschema:"the chapters of the video"`
+	Hashes  []TPDBHash     `json:"hashes,omitempty" jsonschema:"hashes of known files of the video"`
+	Movies  []TPDBVideoRef `json:"movies,omitempty" jsonschema:"the movies a scene is part of"`
+	Scenes  []TPDBVideoRef `json:"scenes,omitempty" jsonschema:"the scenes of a movie"`
+}
+
+func newTPDBVideoRefs(infos []tpdbVideoInfo) []TPDBVideoRef {
+	var refs []TPDBVideoRef
+	for _, info := range infos {
+		refs = append(refs, TPDBVideoRef{
+			ID:    info.Slug,
+			Title: info.Title,
+			Type:  info.Type,
+			Date:  info.Date,
+			Site:  info.Site.Name,
+		})
+	}
+	return refs
+}
+
+func newTPDBVideoDetails(info tpdbVideoInfo) TPDBVideoDetails {
+	details := TPDBVideoDetails{
+		TPDBVideoItem: newTPDBVideoItem(info),
+		URL:           info.URL,
+		Trailer:       info.Trailer,
+		Movies:        newTPDBVideoRefs(info.Movies),
+		Scenes:        newTPDBVideoRefs(info.Scenes),
+	}
+	for _, marker := range info.Markers {
+		details.Markers = append(details.Markers, TPDBMarker{Title: marker.Title, StartTime: marker.StartTime, EndTime: marker.EndTime})
+	}
+	for _, hash := range info.Hashes {
+		details.Hashes = append(details.Hashes, TPDBHash{Hash: hash.Hash, Type: hash.Type, Duration: hash.Duration})
+	}
+	return details
+}
+
+type TPDBGetVideoInput struct {
+	ID    string `json:"id" jsonschema:"the slug or uuid of the video, the id of search_porn results"`
+	Cache string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+// getVideo fetches a scene or a movie, depending on url_.
+func (s *ThePornDB) getVideo(ctx context.Context, url_ string, input TPDBGetVideoInput) (TPDBVideoDetails, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return TPDBVideoDetails{}, errors.New("id is required")
+	}
+
+	res := struct {
+		Data tpdbVideoInfo `json:"data"`
+	}{}
+	if err := s.get(ctx, url_+"/"+url.PathEscape(id), nil, &res); err != nil {
+		return TPDBVideoDetails{}, err
+	}
+	return newTPDBVideoDetails(res.Data), nil
+}
+
+func (s *ThePornDB) getSceneTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBGetVideoInput) (
+	*mcp.CallToolResult, TPDBVideoDetails, error) {
+	result, err := cachedCall(s.cache, "get_porn_scene", input, "", input.Cache == cacheBypass,
+		func() (TPDBVideoDetails, error) {
+			return s.getVideo(ctx, tpdbSearchSceneURL, input)
+		})
+	return nil, result, err
+}
+
+func (s *ThePornDB) getMovieTool(ctx context.Context, req *mcp.CallToolRequest, input TPDBGetVideoInput) (
+	*mcp.CallToolResult, TPDBVideoDetails, error) {
+	result, err := cachedCall(s.cache, "get_porn_movie", input, "", input.Cache == cacheBypass,
+		func() (TPDBVideoDetails, error) {
+			return s.getVideo(ctx, tpdbSearchMovieURL, input)
+		})
+	return nil, result, err
+}
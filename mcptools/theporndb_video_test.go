@@ -0,0 +1,65 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTPDBVideoLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scenes/brazzers-long-con":
+			_, _ = w.Write([]byte(`{"data": {
+				"slug": "brazzers-long-con",
+				"title": "Long Con",
+				"type": "Scene",
+				"date": "2024-03-15",
+				"url": "https://www.brazzers.com/video/1/long-con",
+				"trailer": "https://cdn.theporndb.net/scene/trailer.mp4",
+				"site": {"name": "Brazzers"},
+				"performers": [{"name": "Jane Doe", "extras": {"gender": "Female"}}, {"name": "John Roe", "extras": {"gender": "Male"}}],
+				"markers": [{"title": "Intro", "start_time": 0, "end_time": 95}, {"title": "Heist", "start_time": 95}],
+				"hashes": [{"hash": "0000000000030d43", "type": "OSHASH", "duration": 2460}],
+				"movies": [{"slug": "brazzers-the-con-job", "title": "The Con Job", "type": "Movie", "date": "2024-04-01", "site": {"name": "Brazzers"}}]
+			}}`))
+		case "/movies/brazzers-the-con-job":
+			_, _ = w.Write([]byte(`{"data": {
+				"slug": "brazzers-the-con-job",
+				"title": "The Con Job",
+				"type": "Movie",
+				"scenes": [{"slug": "brazzers-long-con", "title": "Long Con", "type": "Scene", "date": "2024-03-15"}]
+			}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	tpdb := NewThePornDB("token", "", client, nil)
+	scene, err := tpdb.getVideo(t.Context(), tpdbSearchSceneURL, TPDBGetVideoInput{ID: "brazzers-long-con"})
+	require.NoError(t, err)
+	assert.Equal(t, "Long Con", scene.Title)
+	assert.Equal(t, "https://www.brazzers.com/video/1/long-con", scene.URL)
+	assert.Equal(t, "https://cdn.theporndb.net/scene/trailer.mp4", scene.Trailer)
+	assert.Equal(t, []TPDBPerformerItem{{Name: "Jane Doe", Gender: "Female"}, {Name: "John Roe", Gender: "Male"}}, scene.Performers)
+	assert.Equal(t, []TPDBMarker{{Title: "Intro", StartTime: 0, EndTime: 95}, {Title: "Heist", StartTime: 95}}, scene.Markers)
+	assert.Equal(t, []TPDBHash{{Hash: "0000000000030d43", Type: "OSHASH", Duration: 2460}}, scene.Hashes)
+	assert.Equal(t, []TPDBVideoRef{{ID: "brazzers-the-con-job", Title: "The Con Job", Type: "Movie", Date: "2024-04-01", Site: "Brazzers"}}, scene.Movies)
+
+	movie, err := tpdb.getVideo(t.Context(), tpdbSearchMovieURL, TPDBGetVideoInput{ID: "brazzers-the-con-job"})
+	require.NoError(t, err)
+	assert.Equal(t, "The Con Job", movie.Title)
+	assert.Equal(t, []TPDBVideoRef{{ID: "brazzers-long-con", Title: "Long Con", Type: "Scene", Date: "2024-03-15"}}, movie.Scenes)
+
+	_, err = tpdb.getVideo(t.Context(), tpdbSearchSceneURL, TPDBGetVideoInput{})
+	require.ErrorContains(t, err, "id is required")
+}
+
+func TestThePornDBAddTools(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	// AddTool panics on input or output types it can't build a schema for.
+	require.NotPanics(t, func() { NewThePornDB("token", "", nil, nil).AddTools(server) })
+}
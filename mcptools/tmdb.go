@@ -1,26 +1,98 @@
 package mcptools
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"log"
+	"net/http"
+	"net/url"
 	"strconv"
 
 	tmdb "github.com/cyruzin/golang-tmdb"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-const tmdbLimitActorsCount = 10
+const (
+	tmdbAPIURL           = "https://api.themoviedb.org/3"
+	tmdbLimitActorsCount = 10
+	// tmdbMaxConcurrency bounds the per-result detail requests in flight for one search.
+	tmdbMaxConcurrency = 8
+	// tmdbRequestsPerSecond keeps all requests of the process under TMDB's rate limit (~50/s).
+	tmdbRequestsPerSecond = 40
+)
 
 type TMDB struct {
 	apiKey   string
 	language string
+	client   *http.Client
+	cache    *ResponseCache
+	limiter  *rateLimiter
 }
 
-func NewTMDB(apiKey, language string) *TMDB {
+func NewTMDB(apiKey, language string, client *http.Client, cache *ResponseCache) *TMDB {
 	return &TMDB{
 		apiKey:   apiKey,
 		language: language,
+		client:   httpClientOrDefault(client),
+		cache:    cache,
+		limiter:  newRateLimiter(tmdbRequestsPerSecond),
+	}
+}
+
+// httpClient returns the client for TMDB requests, sharing the rate limit of the process.
+func (s *TMDB) httpClient() http.Client {
+	client := *s.client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = withRateLimit(base, s.limiter)
+	return client
+}
+
+// newClient returns a TMDB client whose requests are bound to ctx, the library itself
+// doesn't take a context.
+func (s *TMDB) newClient(ctx context.Context) (*tmdb.Client, error) {
+	c, err := tmdb.Init(s.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	client := s.httpClient()
+	client.Transport = &contextTransport{base: client.Transport, ctx: ctx}
+	c.SetClientConfig(client)
+	return c, nil
+}
+
+// get requests path of the TMDB API directly, for responses the library decodes incompletely.
+func (s *TMDB) get(ctx context.Context, path string, query url.Values, out any) error {
+	u, err := url.Parse(tmdbAPIURL + path)
+	if err != nil {
+		return err
+	}
+	query.Set("api_key", s.apiKey)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	client := s.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkProviderResponse("TMDB", "TMDB_API_KEY", resp); err != nil {
+		return err
 	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// languageFor returns the language requested by a tool call, or the configured one.
+func (s *TMDB) languageFor(language string) string {
+	return cmp.Or(language, s.language)
 }
 
 func (s *TMDB) AddTools(server *mcp.Server) {
@@ -36,11 +108,37 @@ func (s *TMDB) AddTools(server *mcp.Server) {
 		Name:        "find_by_imdb_id",
 		Description: "Finds content on TMDB by IMDB ID using external source lookup.",
 	}, s.findByIMDBTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_people",
+		Description: "Searches for people (actors, directors, ...) on The Movie Database (TMDB) by name, returns biography, birthday and also-known-as names.",
+	}, s.searchPeopleTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_person",
+		Description: "Gets a person on The Movie Database (TMDB) by TMDB person ID, including biography, also-known-as names and filmography.",
+	}, s.getPersonTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_tv_season",
+		Description: "Gets a season of a TV show on The Movie Database (TMDB) by TMDB show ID and season number, listing every episode with title, air date, overview, runtime and guest stars.",
+	}, s.getTVSeasonTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_tv_episode",
+		Description: "Gets a single episode of a TV show on The Movie Database (TMDB) by TMDB show ID, season number and episode number.",
+	}, s.getTVEpisodeTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_movie",
+		Description: "Gets a movie on The Movie Database (TMDB) by TMDB movie ID, including its IMDB ID and actors.",
+	}, s.getMovieTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_tv_show",
+		Description: "Gets a TV show on The Movie Database (TMDB) by TMDB show ID, including its IMDB and TVDB IDs, actors and seasons.",
+	}, s.getTVShowTool)
 }
 
 type TMDBSearchMovieInput struct {
-	Name string `json:"name" jsonschema:"the name of the movie or tv show to search for"`
-	Year int    `json:"year,omitempty" jsonschema:"(optional) the year of the movie released"`
+	Name     string `json:"name" jsonschema:"the name of the movie or tv show to search for"`
+	Year     int    `json:"year,omitempty" jsonschema:"(optional) the year of the movie released"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
 }
 
 type TMDBActor struct {
@@ -49,6 +147,8 @@ type TMDBActor struct {
 }
 
 type TMDBMovieItem struct {
+	ID               int64       `json:"id" jsonschema:"the TMDB ID"`
+	IMDBID           string      `json:"imdb_id,omitempty"`
 	Title            string      `json:"title"`
 	OriginalTitle    string      `json:"original_title"`
 	OriginalLanguage string      `json:"original_language"`
@@ -61,14 +161,15 @@ type SearchMovieOutput struct {
 	Results []TMDBMovieItem `json:"results"`
 }
 
-func (s *TMDB) searchMovies(input TMDBSearchMovieInput) (SearchMovieOutput, error) {
-	c, err := tmdb.Init(s.apiKey)
+func (s *TMDB) searchMovies(ctx context.Context, input TMDBSearchMovieInput) (SearchMovieOutput, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
 	if err != nil {
 		log.Printf("Error initializing TMDB client: %v", err)
 		return SearchMovieOutput{}, err
 	}
 
-	options := map[string]string{"language": s.language, "include_adult": "true"}
+	options := map[string]string{"language": language, "include_adult": "true"}
 	if input.Year != 0 {
 		options["year"] = strconv.Itoa(input.Year)
 	}
@@ -79,38 +180,25 @@ func (s *TMDB) searchMovies(input TMDBSearchMovieInput) (SearchMovieOutput, erro
 		return SearchMovieOutput{}, err
 	}
 
-	var results []TMDBMovieItem
-	for _, movie := range searchRes.Results {
-		movieItem := TMDBMovieItem{
-			Title:            movie.Title,
-			OriginalTitle:    movie.OriginalTitle,
-			OriginalLanguage: movie.OriginalLanguage,
-			Overview:         movie.Overview,
-			ReleaseDate:      movie.ReleaseDate,
-		}
-
-		var actors []TMDBActor
-		options := map[string]string{"language": s.language}
-		credits, err := c.GetMovieCredits(int(movie.ID), options)
-		if err != nil {
-			log.Printf("Error getting movie credits: %v", err)
-		} else {
-			for _, cast := range credits.Cast {
-				if len(actors) >= tmdbLimitActorsCount {
-					break
+	results, err := parallelMap(ctx, searchRes.Results, tmdbMaxConcurrency,
+		func(ctx context.Context, movie tmdb.MovieResult) TMDBMovieItem {
+			movieItem, err := s.getMovieDetails(c, language, int(movie.ID))
+			if err != nil {
+				log.Printf("Error getting movie details: %v", err)
+				// Use basic info from search results
+				movieItem = TMDBMovieItem{
+					ID:               movie.ID,
+					Title:            movie.Title,
+					OriginalTitle:    movie.OriginalTitle,
+					OriginalLanguage: movie.OriginalLanguage,
+					Overview:         movie.Overview,
+					ReleaseDate:      movie.ReleaseDate,
 				}
-				if cast.KnownForDepartment != "Acting" {
-					continue
-				}
-				actors = append(actors, TMDBActor{
-					Name:         cast.Name,
-					OriginalName: cast.OriginalName,
-				})
 			}
-			movieItem.Actors = actors
-		}
-
-		results = append(results, movieItem)
+			return movieItem
+		})
+	if err != nil {
+		return SearchMovieOutput{}, err
 	}
 
 	return SearchMovieOutput{Results: results}, nil
@@ -119,24 +207,99 @@ func (s *TMDB) searchMovies(input TMDBSearchMovieInput) (SearchMovieOutput, erro
 func (s *TMDB) searchMoviesTool(
 	ctx context.Context, req *mcp.CallToolRequest, input TMDBSearchMovieInput) (
 	*mcp.CallToolResult, SearchMovieOutput, error) {
-	result, err := s.searchMovies(input)
+	result, err := cachedCall(s.cache, "search_movies", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (SearchMovieOutput, error) {
+			return s.searchMovies(ctx, input)
+		})
+	return nil, result, err
+}
+
+type TMDBGetMovieInput struct {
+	ID       int    `json:"id" jsonschema:"the TMDB movie ID"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+func (s *TMDB) getMovieDetails(c *tmdb.Client, language string, movieID int) (TMDBMovieItem, error) {
+	detailOptions := map[string]string{"language": language, "append_to_response": "credits"}
+	details, err := c.GetMovieDetails(movieID, detailOptions)
+	if err != nil {
+		return TMDBMovieItem{}, err
+	}
+
+	movieItem := TMDBMovieItem{
+		ID:               details.ID,
+		IMDBID:           details.IMDbID,
+		Title:            details.Title,
+		OriginalTitle:    details.OriginalTitle,
+		OriginalLanguage: details.OriginalLanguage,
+		Overview:         details.Overview,
+		ReleaseDate:      details.ReleaseDate,
+	}
+
+	if details.MovieCreditsAppend != nil {
+		for _, cast := range details.Credits.Cast {
+			if len(movieItem.Actors) >= tmdbLimitActorsCount {
+				break
+			}
+			if cast.KnownForDepartment != "Acting" {
+				continue
+			}
+			movieItem.Actors = append(movieItem.Actors, TMDBActor{
+				Name:         cast.Name,
+				OriginalName: cast.OriginalName,
+			})
+		}
+	}
+
+	return movieItem, nil
+}
+
+func (s *TMDB) getMovie(ctx context.Context, input TMDBGetMovieInput) (TMDBMovieItem, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
+	if err != nil {
+		log.Printf("Error initializing TMDB client: %v", err)
+		return TMDBMovieItem{}, err
+	}
+
+	movieItem, err := s.getMovieDetails(c, language, input.ID)
+	if err != nil {
+		log.Printf("Error getting movie details: %v", err)
+		return TMDBMovieItem{}, err
+	}
+	return movieItem, nil
+}
+
+func (s *TMDB) getMovieTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBGetMovieInput) (
+	*mcp.CallToolResult, TMDBMovieItem, error) {
+	result, err := cachedCall(s.cache, "get_movie", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBMovieItem, error) {
+			return s.getMovie(ctx, input)
+		})
 	return nil, result, err
 }
 
-func (s *TMDB) getTVDetails(c *tmdb.Client, tvShowID int) (TMDBTVShowItem, error) {
-	detailOptions := map[string]string{"language": s.language, "append_to_response": "credits"}
+func (s *TMDB) getTVDetails(c *tmdb.Client, language string, tvShowID int) (TMDBTVShowItem, error) {
+	detailOptions := map[string]string{"language": language, "append_to_response": "credits,external_ids"}
 	details, err := c.GetTVDetails(tvShowID, detailOptions)
 	if err != nil {
 		return TMDBTVShowItem{}, err
 	}
 
 	tvItem := TMDBTVShowItem{
+		ID:               details.ID,
 		Name:             details.Name,
 		OriginalName:     details.OriginalName,
 		OriginalLanguage: details.OriginalLanguage,
 		Overview:         details.Overview,
 		FirstAirDate:     details.FirstAirDate,
 	}
+	if details.TVExternalIDsAppend != nil && details.TVExternalIDs != nil {
+		tvItem.IMDBID = details.TVExternalIDs.IMDbID
+		tvItem.TVDBID = details.TVExternalIDs.TVDBID
+	}
 
 	// get seasons
 	for _, season := range details.Seasons {
@@ -149,24 +312,28 @@ func (s *TMDB) getTVDetails(c *tmdb.Client, tvShowID int) (TMDBTVShowItem, error
 	}
 
 	// get actors
-	for _, cast := range details.Credits.Cast {
-		if len(tvItem.Actors) >= tmdbLimitActorsCount {
-			break
-		}
-		if cast.KnownForDepartment != "Acting" {
-			continue
+	if details.TVCreditsAppend != nil {
+		for _, cast := range details.Credits.Cast {
+			if len(tvItem.Actors) >= tmdbLimitActorsCount {
+				break
+			}
+			if cast.KnownForDepartment != "Acting" {
+				continue
+			}
+			tvItem.Actors = append(tvItem.Actors, TMDBActor{
+				Name:         cast.Name,
+				OriginalName: cast.OriginalName,
+			})
 		}
-		tvItem.Actors = append(tvItem.Actors, TMDBActor{
-			Name:         cast.Name,
-			OriginalName: cast.OriginalName,
-		})
 	}
 
 	return tvItem, nil
 }
 
 type TMDBSearchTVShowInput struct {
-	Name string `json:"name" jsonschema:"the name of the movie or tv show to search for"`
+	Name     string `json:"name" jsonschema:"the name of the movie or tv show to search for"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
 }
 
 type TMDBTVShowSeason struct {
@@ -177,6 +344,9 @@ type TMDBTVShowSeason struct {
 }
 
 type TMDBTVShowItem struct {
+	ID               int64              `json:"id" jsonschema:"the TMDB ID"`
+	IMDBID           string             `json:"imdb_id,omitempty"`
+	TVDBID           int64              `json:"tvdb_id,omitempty"`
 	Name             string             `json:"name"`
 	OriginalName     string             `json:"original_name"`
 	OriginalLanguage string             `json:"original_language"`
@@ -186,39 +356,76 @@ type TMDBTVShowItem struct {
 	Seasons          []TMDBTVShowSeason `json:"seasons,omitempty"`
 }
 
+type TMDBGetTVShowInput struct {
+	ID       int    `json:"id" jsonschema:"the TMDB tv show ID"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+func (s *TMDB) getTVShow(ctx context.Context, input TMDBGetTVShowInput) (TMDBTVShowItem, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
+	if err != nil {
+		log.Printf("Error initializing TMDB client: %v", err)
+		return TMDBTVShowItem{}, err
+	}
+
+	tvItem, err := s.getTVDetails(c, language, input.ID)
+	if err != nil {
+		log.Printf("Error getting tv details: %v", err)
+		return TMDBTVShowItem{}, err
+	}
+	return tvItem, nil
+}
+
+func (s *TMDB) getTVShowTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBGetTVShowInput) (
+	*mcp.CallToolResult, TMDBTVShowItem, error) {
+	result, err := cachedCall(s.cache, "get_tv_show", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBTVShowItem, error) {
+			return s.getTVShow(ctx, input)
+		})
+	return nil, result, err
+}
+
 type SearchTVShowOutput struct {
 	Results []TMDBTVShowItem `json:"results"`
 }
 
-func (s *TMDB) searchTVShows(input TMDBSearchTVShowInput) (SearchTVShowOutput, error) {
-	c, err := tmdb.Init(s.apiKey)
+func (s *TMDB) searchTVShows(ctx context.Context, input TMDBSearchTVShowInput) (SearchTVShowOutput, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
 	if err != nil {
 		log.Printf("Error initializing TMDB client: %v", err)
 		return SearchTVShowOutput{}, err
 	}
 
-	options := map[string]string{"language": s.language, "include_adult": "true"}
+	options := map[string]string{"language": language, "include_adult": "true"}
 	searchRes, err := c.GetSearchTVShow(input.Name, options)
 	if err != nil {
 		log.Printf("Error searching tv shows: %v", err)
 		return SearchTVShowOutput{}, err
 	}
 
-	var results []TMDBTVShowItem
-	for _, tvShow := range searchRes.Results {
-		tvItem, err := s.getTVDetails(c, int(tvShow.ID))
-		if err != nil {
-			log.Printf("Error getting tv details: %v", err)
-			// Use basic info from search results
-			tvItem = TMDBTVShowItem{
-				Name:             tvShow.Name,
-				OriginalName:     tvShow.OriginalName,
-				OriginalLanguage: tvShow.OriginalLanguage,
-				Overview:         tvShow.Overview,
-				FirstAirDate:     tvShow.FirstAirDate,
+	results, err := parallelMap(ctx, searchRes.Results, tmdbMaxConcurrency,
+		func(ctx context.Context, tvShow tmdb.TVShowResult) TMDBTVShowItem {
+			tvItem, err := s.getTVDetails(c, language, int(tvShow.ID))
+			if err != nil {
+				log.Printf("Error getting tv details: %v", err)
+				// Use basic info from search results
+				tvItem = TMDBTVShowItem{
+					ID:               tvShow.ID,
+					Name:             tvShow.Name,
+					OriginalName:     tvShow.OriginalName,
+					OriginalLanguage: tvShow.OriginalLanguage,
+					Overview:         tvShow.Overview,
+					FirstAirDate:     tvShow.FirstAirDate,
+				}
 			}
-		}
-		results = append(results, tvItem)
+			return tvItem
+		})
+	if err != nil {
+		return SearchTVShowOutput{}, err
 	}
 
 	return SearchTVShowOutput{Results: results}, nil
@@ -227,21 +434,17 @@ func (s *TMDB) searchTVShows(input TMDBSearchTVShowInput) (SearchTVShowOutput, e
 func (s *TMDB) searchTVShowsTool(
 	ctx context.Context, req *mcp.CallToolRequest, input TMDBSearchTVShowInput) (
 	*mcp.CallToolResult, SearchTVShowOutput, error) {
-	result, err := s.searchTVShows(input)
+	result, err := cachedCall(s.cache, "search_tv_shows", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (SearchTVShowOutput, error) {
+			return s.searchTVShows(ctx, input)
+		})
 	return nil, result, err
 }
 
 type TMDBFindByIMDBInput struct {
-	IMDBID string `json:"imdb_id" jsonschema:"the IMDB ID to search for (e.g., 'tt0111161')"`
-}
-
-type TMDBPerson struct {
-	Name         string  `json:"name"`
-	OriginalName string  `json:"original_name"`
-	Birthday     string  `json:"birthday,omitempty"`
-	PlaceOfBirth string  `json:"place_of_birth,omitempty"`
-	Deathday     string  `json:"deathday,omitempty"`
-	Popularity   float32 `json:"popularity,omitempty"`
+	IMDBID   string `json:"imdb_id" jsonschema:"the IMDB ID to search for (e.g., 'tt0111161')"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
 }
 
 type TMDBFindByIMDBOutput struct {
@@ -250,15 +453,16 @@ type TMDBFindByIMDBOutput struct {
 	PersonResults []TMDBPerson     `json:"person_results,omitempty"`
 }
 
-func (s *TMDB) findByIMDB(input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, error) {
-	c, err := tmdb.Init(s.apiKey)
+func (s *TMDB) findByIMDB(ctx context.Context, input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
 	if err != nil {
 		log.Printf("Error initializing TMDB client: %v", err)
 		return TMDBFindByIMDBOutput{}, err
 	}
 
 	options := map[string]string{
-		"language":        s.language,
+		"language":        language,
 		"external_source": "imdb_id",
 	}
 
@@ -272,41 +476,17 @@ func (s *TMDB) findByIMDB(input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, erro
 
 	// Handle movie results
 	for _, movie := range findResult.MovieResults {
-		detailOptions := map[string]string{"language": s.language, "append_to_response": "credits"}
-		details, err := c.GetMovieDetails(int(movie.ID), detailOptions)
+		movieItem, err := s.getMovieDetails(c, language, int(movie.ID))
 		if err != nil {
 			log.Printf("Error getting movie details: %v", err)
 			continue
 		}
-
-		movieItem := TMDBMovieItem{
-			Title:            details.Title,
-			OriginalTitle:    details.OriginalTitle,
-			OriginalLanguage: details.OriginalLanguage,
-			Overview:         details.Overview,
-			ReleaseDate:      details.ReleaseDate,
-		}
-
-		var actors []TMDBActor
-		for _, cast := range details.Credits.Cast {
-			if len(actors) >= tmdbLimitActorsCount {
-				break
-			}
-			if cast.KnownForDepartment != "Acting" {
-				continue
-			}
-			actors = append(actors, TMDBActor{
-				Name:         cast.Name,
-				OriginalName: cast.OriginalName,
-			})
-		}
-		movieItem.Actors = actors
 		result.MovieResults = append(result.MovieResults, movieItem)
 	}
 
 	// Handle TV results
 	for _, tvShow := range findResult.TvResults {
-		tvItem, err := s.getTVDetails(c, int(tvShow.ID))
+		tvItem, err := s.getTVDetails(c, language, int(tvShow.ID))
 		if err != nil {
 			log.Printf("Error getting tv details: %v", err)
 			continue
@@ -316,7 +496,7 @@ func (s *TMDB) findByIMDB(input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, erro
 
 	// Handle TV episode results (find the TV series)
 	for _, episode := range findResult.TvEpisodeResults {
-		tvItem, err := s.getTVDetails(c, int(episode.ShowID))
+		tvItem, err := s.getTVDetails(c, language, int(episode.ShowID))
 		if err != nil {
 			log.Printf("Error getting tv series details for episode: %v", err)
 			continue
@@ -326,7 +506,7 @@ func (s *TMDB) findByIMDB(input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, erro
 
 	// Handle TV season results (find the TV series)
 	for _, season := range findResult.TvSeasonResults {
-		tvItem, err := s.getTVDetails(c, int(season.ShowID))
+		tvItem, err := s.getTVDetails(c, language, int(season.ShowID))
 		if err != nil {
 			log.Printf("Error getting tv series details for season: %v", err)
 			continue
@@ -336,20 +516,34 @@ func (s *TMDB) findByIMDB(input TMDBFindByIMDBInput) (TMDBFindByIMDBOutput, erro
 
 	// Handle person results
 	for _, person := range findResult.PersonResults {
-		personItem := TMDBPerson{
-			Name:         person.Name,
-			OriginalName: person.Name, // Person API doesn't have OriginalName
-			Popularity:   person.Popularity,
+		personItem, err := s.getPersonDetails(c, language, int(person.ID))
+		if err != nil {
+			log.Printf("Error getting person details: %v", err)
+			// Use basic info from find results
+			personItem = TMDBPerson{
+				ID:                 person.ID,
+				Name:               person.Name,
+				OriginalName:       person.Name,
+				KnownForDepartment: person.KnownForDepartment,
+				Popularity:         person.Popularity,
+			}
 		}
 		result.PersonResults = append(result.PersonResults, personItem)
 	}
 
+	// Detail lookups above only log their errors, don't return partial results of a cancelled call.
+	if err := ctx.Err(); err != nil {
+		return TMDBFindByIMDBOutput{}, err
+	}
 	return result, nil
 }
 
 func (s *TMDB) findByIMDBTool(
 	ctx context.Context, req *mcp.CallToolRequest, input TMDBFindByIMDBInput) (
 	*mcp.CallToolResult, TMDBFindByIMDBOutput, error) {
-	result, err := s.findByIMDB(input)
+	result, err := cachedCall(s.cache, "find_by_imdb_id", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBFindByIMDBOutput, error) {
+			return s.findByIMDB(ctx, input)
+		})
 	return nil, result, err
 }
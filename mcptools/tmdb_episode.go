@@ -0,0 +1,155 @@
+package mcptools
+
+import (
+	"context"
+	"log"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TMDBGuestStar struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Character string `json:"character,omitempty"`
+}
+
+type TMDBTVEpisode struct {
+	ID            int64           `json:"id"`
+	SeasonNumber  int             `json:"season_number"`
+	EpisodeNumber int             `json:"episode_number"`
+	Name          string          `json:"name"`
+	AirDate       string          `json:"air_date,omitempty"`
+	Overview      string          `json:"overview,omitempty"`
+	Runtime       int             `json:"runtime,omitempty" jsonschema:"runtime in minutes"`
+	GuestStars    []TMDBGuestStar `json:"guest_stars,omitempty"`
+}
+
+type TMDBGetTVSeasonInput struct {
+	ShowID       int    `json:"show_id" jsonschema:"the TMDB ID of the tv show"`
+	SeasonNumber int    `json:"season_number" jsonschema:"the season number, 0 is specials"`
+	Language     string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache        string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TMDBTVSeason struct {
+	ShowID       int             `json:"show_id"`
+	SeasonNumber int             `json:"season_number"`
+	Name         string          `json:"name"`
+	Overview     string          `json:"overview,omitempty"`
+	AirDate      string          `json:"air_date,omitempty"`
+	Episodes     []TMDBTVEpisode `json:"episodes,omitempty"`
+}
+
+func (s *TMDB) getTVSeason(ctx context.Context, input TMDBGetTVSeasonInput) (TMDBTVSeason, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
+	if err != nil {
+		log.Printf("Error initializing TMDB client: %v", err)
+		return TMDBTVSeason{}, err
+	}
+
+	options := map[string]string{"language": language}
+	details, err := c.GetTVSeasonDetails(input.ShowID, input.SeasonNumber, options)
+	if err != nil {
+		log.Printf("Error getting tv season details: %v", err)
+		return TMDBTVSeason{}, err
+	}
+
+	season := TMDBTVSeason{
+		ShowID:       input.ShowID,
+		SeasonNumber: details.SeasonNumber,
+		Name:         details.Name,
+		Overview:     details.Overview,
+		AirDate:      details.AirDate,
+	}
+	for _, episode := range details.Episodes {
+		episodeItem := TMDBTVEpisode{
+			ID:            episode.ID,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			Name:          episode.Name,
+			AirDate:       episode.AirDate,
+			Overview:      episode.Overview,
+			Runtime:       episode.Runtime,
+		}
+		for _, guest := range episode.GuestStars {
+			if len(episodeItem.GuestStars) >= tmdbLimitActorsCount {
+				break
+			}
+			episodeItem.GuestStars = append(episodeItem.GuestStars, TMDBGuestStar{
+				ID:        guest.ID,
+				Name:      guest.Name,
+				Character: guest.Character,
+			})
+		}
+		season.Episodes = append(season.Episodes, episodeItem)
+	}
+
+	return season, nil
+}
+
+func (s *TMDB) getTVSeasonTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBGetTVSeasonInput) (
+	*mcp.CallToolResult, TMDBTVSeason, error) {
+	result, err := cachedCall(s.cache, "get_tv_season", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBTVSeason, error) {
+			return s.getTVSeason(ctx, input)
+		})
+	return nil, result, err
+}
+
+type TMDBGetTVEpisodeInput struct {
+	ShowID        int    `json:"show_id" jsonschema:"the TMDB ID of the tv show"`
+	SeasonNumber  int    `json:"season_number" jsonschema:"the season number, 0 is specials"`
+	EpisodeNumber int    `json:"episode_number" jsonschema:"the episode number within the season"`
+	Language      string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache         string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+func (s *TMDB) getTVEpisode(ctx context.Context, input TMDBGetTVEpisodeInput) (TMDBTVEpisode, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
+	if err != nil {
+		log.Printf("Error initializing TMDB client: %v", err)
+		return TMDBTVEpisode{}, err
+	}
+
+	options := map[string]string{"language": language}
+	details, err := c.GetTVEpisodeDetails(input.ShowID, input.SeasonNumber, input.EpisodeNumber, options)
+	if err != nil {
+		log.Printf("Error getting tv episode details: %v", err)
+		return TMDBTVEpisode{}, err
+	}
+
+	episode := TMDBTVEpisode{
+		ID:            details.ID,
+		SeasonNumber:  details.SeasonNumber,
+		EpisodeNumber: details.EpisodeNumber,
+		Name:          details.Name,
+		AirDate:       details.AirDate,
+		Overview:      details.Overview,
+		Runtime:       details.Runtime,
+	}
+	for _, guest := range details.GuestStars {
+		if len(episode.GuestStars) >= tmdbLimitActorsCount {
+			break
+		}
+		episode.GuestStars = append(episode.GuestStars, TMDBGuestStar{
+			ID:        guest.ID,
+			Name:      guest.Name,
+			Character: guest.Character,
+		})
+	}
+
+	return episode, nil
+}
+
+func (s *TMDB) getTVEpisodeTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBGetTVEpisodeInput) (
+	*mcp.CallToolResult, TMDBTVEpisode, error) {
+	result, err := cachedCall(s.cache, "get_tv_episode", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBTVEpisode, error) {
+			return s.getTVEpisode(ctx, input)
+		})
+	return nil, result, err
+}
@@ -0,0 +1,83 @@
+package mcptools
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTVSeason(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	// Breaking Bad
+	result, err := tmdb.getTVSeason(t.Context(), TMDBGetTVSeasonInput{ShowID: 1396, SeasonNumber: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Episodes, 7)
+	assert.Equal(t, "Pilot", result.Episodes[0].Name)
+	assert.Equal(t, "2008-01-20", result.Episodes[0].AirDate)
+}
+
+func TestGetTVEpisode(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	result, err := tmdb.getTVEpisode(t.Context(), TMDBGetTVEpisodeInput{ShowID: 1396, SeasonNumber: 1, EpisodeNumber: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Pilot", result.Name)
+	assert.NotEmpty(t, result.Overview)
+	assert.NotZero(t, result.Runtime)
+}
+
+func TestGetTVSeasonLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/tv/1/season/2", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"name": "Season 2",
+			"season_number": 2,
+			"air_date": "2020-04-01",
+			"episodes": [
+				{
+					"id": 20, "season_number": 2, "episode_number": 1, "name": "First",
+					"air_date": "2020-04-01", "runtime": 45,
+					"guest_stars": [{"id": 5, "name": "Guest", "character": "Doctor"}]
+				},
+				{"id": 21, "season_number": 2, "episode_number": 2, "name": "Second"}
+			]
+		}`))
+	}))
+	tmdb := NewTMDB("key", "en-US", client, nil)
+
+	result, err := tmdb.getTVSeason(t.Context(), TMDBGetTVSeasonInput{ShowID: 1, SeasonNumber: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ShowID)
+	assert.Equal(t, "Season 2", result.Name)
+	require.Len(t, result.Episodes, 2)
+	assert.Equal(t, TMDBTVEpisode{
+		ID: 20, SeasonNumber: 2, EpisodeNumber: 1, Name: "First", AirDate: "2020-04-01", Runtime: 45,
+		GuestStars: []TMDBGuestStar{{ID: 5, Name: "Guest", Character: "Doctor"}},
+	}, result.Episodes[0])
+	assert.Equal(t, "Second", result.Episodes[1].Name)
+}
+
+func TestGetTVEpisodeLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/tv/1/season/2/episode/3", r.URL.Path)
+		var guests []string
+		for i := range tmdbLimitActorsCount + 2 {
+			guests = append(guests, fmt.Sprintf(`{"id": %d, "name": "Guest %d"}`, i, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"id": 30, "season_number": 2, "episode_number": 3, "name": "Third", "guest_stars": [%s]}`,
+			strings.Join(guests, ","))
+	}))
+	tmdb := NewTMDB("key", "en-US", client, nil)
+
+	result, err := tmdb.getTVEpisode(t.Context(), TMDBGetTVEpisodeInput{ShowID: 1, SeasonNumber: 2, EpisodeNumber: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "Third", result.Name)
+	// Guest stars are capped like in seasons.
+	require.Len(t, result.GuestStars, tmdbLimitActorsCount)
+	assert.Equal(t, "Guest 0", result.GuestStars[0].Name)
+}
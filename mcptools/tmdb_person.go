@@ -0,0 +1,237 @@
+package mcptools
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"net/url"
+	"slices"
+	"strconv"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	tmdbLimitPeopleCount      = 10
+	tmdbLimitFilmographyCount = 50
+)
+
+type TMDBCredit struct {
+	ID            int64  `json:"id"`
+	MediaType     string `json:"media_type" jsonschema:"movie or tv"`
+	Title         string `json:"title"`
+	OriginalTitle string `json:"original_title,omitempty"`
+	Character     string `json:"character,omitempty"`
+	Job           string `json:"job,omitempty"`
+	Date          string `json:"date,omitempty" jsonschema:"release date of the movie or first air date of the tv show"`
+}
+
+type TMDBPerson struct {
+	ID                 int64        `json:"id" jsonschema:"the TMDB ID"`
+	IMDBID             string       `json:"imdb_id,omitempty"`
+	Name               string       `json:"name"`
+	OriginalName       string       `json:"original_name" jsonschema:"same as name, TMDB has no original name for people"`
+	AlsoKnownAs        []string     `json:"also_known_as,omitempty" jsonschema:"other names of the person, usually including the name in their native language"`
+	KnownForDepartment string       `json:"known_for_department,omitempty"`
+	Biography          string       `json:"biography,omitempty"`
+	Birthday           string       `json:"birthday,omitempty"`
+	PlaceOfBirth       string       `json:"place_of_birth,omitempty"`
+	Deathday           string       `json:"deathday,omitempty"`
+	Popularity         float32      `json:"popularity,omitempty"`
+	KnownFor           []TMDBCredit `json:"known_for,omitempty"`
+	Filmography        []TMDBCredit `json:"filmography,omitempty" jsonschema:"credits of the person, newest first"`
+}
+
+// tmdbCombinedCredit is an entry of a person's combined credits. The library leaves out the
+// tv fields of crew entries, so they are decoded here.
+type tmdbCombinedCredit struct {
+	ID            int64  `json:"id"`
+	MediaType     string `json:"media_type"`
+	Title         string `json:"title"`
+	OriginalTitle string `json:"original_title"`
+	Name          string `json:"name"`
+	OriginalName  string `json:"original_name"`
+	Character     string `json:"character"`
+	Job           string `json:"job"`
+	ReleaseDate   string `json:"release_date"`
+	FirstAirDate  string `json:"first_air_date"`
+}
+
+func (c tmdbCombinedCredit) credit() TMDBCredit {
+	return TMDBCredit{
+		ID:            c.ID,
+		MediaType:     c.MediaType,
+		Title:         cmp.Or(c.Title, c.Name),
+		OriginalTitle: cmp.Or(c.OriginalTitle, c.OriginalName),
+		Character:     c.Character,
+		Job:           c.Job,
+		Date:          cmp.Or(c.ReleaseDate, c.FirstAirDate),
+	}
+}
+
+// tmdbPersonWithCredits is a person with combined_credits appended, the combined_credits
+// field shadows the library's one.
+type tmdbPersonWithCredits struct {
+	tmdb.PersonDetails
+	CombinedCredits *struct {
+		Cast []tmdbCombinedCredit `json:"cast"`
+		Crew []tmdbCombinedCredit `json:"crew"`
+	} `json:"combined_credits"`
+}
+
+func newTMDBPerson(details *tmdb.PersonDetails) TMDBPerson {
+	return TMDBPerson{
+		ID:                 details.ID,
+		IMDBID:             details.IMDbID,
+		Name:               details.Name,
+		OriginalName:       details.Name, // Person API doesn't have OriginalName
+		AlsoKnownAs:        details.AlsoKnownAs,
+		KnownForDepartment: details.KnownForDepartment,
+		Biography:          details.Biography,
+		Birthday:           details.Birthday,
+		PlaceOfBirth:       details.PlaceOfBirth,
+		Deathday:           details.Deathday,
+		Popularity:         details.Popularity,
+	}
+}
+
+// getPersonDetails fetches a person without the filmography.
+func (s *TMDB) getPersonDetails(c *tmdb.Client, language string, personID int) (TMDBPerson, error) {
+	details, err := c.GetPersonDetails(personID, map[string]string{"language": language})
+	if err != nil {
+		return TMDBPerson{}, err
+	}
+	return newTMDBPerson(details), nil
+}
+
+// getPersonWithFilmography fetches a person with the filmography from combined credits.
+func (s *TMDB) getPersonWithFilmography(ctx context.Context, language string, personID int) (TMDBPerson, error) {
+	details := tmdbPersonWithCredits{}
+	query := url.Values{"language": {language}, "append_to_response": {"combined_credits"}}
+	if err := s.get(ctx, "/person/"+strconv.Itoa(personID), query, &details); err != nil {
+		return TMDBPerson{}, err
+	}
+
+	person := newTMDBPerson(&details.PersonDetails)
+	if details.CombinedCredits != nil {
+		for _, credit := range slices.Concat(details.CombinedCredits.Cast, details.CombinedCredits.Crew) {
+			person.Filmography = append(person.Filmography, credit.credit())
+		}
+		// Newest first, undated (usually announced) credits last.
+		slices.SortStableFunc(person.Filmography, func(a, b TMDBCredit) int {
+			if (a.Date == "") != (b.Date == "") {
+				if a.Date == "" {
+					return 1
+				}
+				return -1
+			}
+			return cmp.Compare(b.Date, a.Date)
+		})
+		if len(person.Filmography) > tmdbLimitFilmographyCount {
+			person.Filmography = person.Filmography[:tmdbLimitFilmographyCount]
+		}
+	}
+
+	return person, nil
+}
+
+type TMDBSearchPeopleInput struct {
+	Name     string `json:"name" jsonschema:"the name of the person to search for, in any language"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+type TMDBSearchPeopleOutput struct {
+	Results []TMDBPerson `json:"results"`
+}
+
+func (s *TMDB) searchPeople(ctx context.Context, input TMDBSearchPeopleInput) (TMDBSearchPeopleOutput, error) {
+	language := s.languageFor(input.Language)
+	c, err := s.newClient(ctx)
+	if err != nil {
+		log.Printf("Error initializing TMDB client: %v", err)
+		return TMDBSearchPeopleOutput{}, err
+	}
+
+	options := map[string]string{"language": language, "include_adult": "true"}
+	searchRes, err := c.GetSearchPeople(input.Name, options)
+	if err != nil {
+		log.Printf("Error searching people: %v", err)
+		return TMDBSearchPeopleOutput{}, err
+	}
+
+	people := searchRes.Results[:min(len(searchRes.Results), tmdbLimitPeopleCount)]
+	// The search result element is an anonymous struct, map over indices instead.
+	indices := make([]int, len(people))
+	for i := range indices {
+		indices[i] = i
+	}
+	results, err := parallelMap(ctx, indices, tmdbMaxConcurrency,
+		func(ctx context.Context, i int) TMDBPerson {
+			person := people[i]
+			personItem, err := s.getPersonDetails(c, language, int(person.ID))
+			if err != nil {
+				log.Printf("Error getting person details: %v", err)
+				// Use basic info from search results
+				personItem = TMDBPerson{
+					ID:                 person.ID,
+					Name:               person.Name,
+					OriginalName:       person.Name,
+					KnownForDepartment: person.KnownForDepartment,
+					Popularity:         person.Popularity,
+				}
+			}
+			for _, known := range person.KnownFor {
+				personItem.KnownFor = append(personItem.KnownFor, TMDBCredit{
+					ID:            known.ID,
+					MediaType:     known.MediaType,
+					Title:         cmp.Or(known.Title, known.Name),
+					OriginalTitle: cmp.Or(known.OriginalTitle, known.OriginalName),
+					Date:          cmp.Or(known.ReleaseDate, known.FirstAirDate),
+				})
+			}
+			return personItem
+		})
+	if err != nil {
+		return TMDBSearchPeopleOutput{}, err
+	}
+
+	return TMDBSearchPeopleOutput{Results: results}, nil
+}
+
+func (s *TMDB) searchPeopleTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBSearchPeopleInput) (
+	*mcp.CallToolResult, TMDBSearchPeopleOutput, error) {
+	result, err := cachedCall(s.cache, "search_people", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBSearchPeopleOutput, error) {
+			return s.searchPeople(ctx, input)
+		})
+	return nil, result, err
+}
+
+type TMDBGetPersonInput struct {
+	ID       int    `json:"id" jsonschema:"the TMDB person ID, e.g. from search_people"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the response language, e.g. 'en-US' or 'zh-CN', defaults to the configured language"`
+	Cache    string `json:"cache,omitempty" jsonschema:"(optional) set to 'bypass' to skip cached results"`
+}
+
+func (s *TMDB) getPerson(ctx context.Context, input TMDBGetPersonInput) (TMDBPerson, error) {
+	language := s.languageFor(input.Language)
+	person, err := s.getPersonWithFilmography(ctx, language, input.ID)
+	if err != nil {
+		log.Printf("Error getting person details: %v", err)
+		return TMDBPerson{}, err
+	}
+	return person, nil
+}
+
+func (s *TMDB) getPersonTool(
+	ctx context.Context, req *mcp.CallToolRequest, input TMDBGetPersonInput) (
+	*mcp.CallToolResult, TMDBPerson, error) {
+	result, err := cachedCall(s.cache, "get_person", input, s.languageFor(input.Language), input.Cache == cacheBypass,
+		func() (TMDBPerson, error) {
+			return s.getPerson(ctx, input)
+		})
+	return nil, result, err
+}
@@ -0,0 +1,72 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPeople(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	result, err := tmdb.searchPeople(t.Context(), TMDBSearchPeopleInput{Name: "Steve Martin"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Results)
+	assert.Equal(t, "Steve Martin", result.Results[0].Name)
+	assert.NotEmpty(t, result.Results[0].Birthday)
+	assert.NotEmpty(t, result.Results[0].KnownFor)
+}
+
+func TestGetPerson(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	// Steve Martin
+	result, err := tmdb.getPerson(t.Context(), TMDBGetPersonInput{ID: 67773})
+	require.NoError(t, err)
+	assert.Equal(t, "Steve Martin", result.Name)
+	assert.NotEmpty(t, result.Biography)
+	assert.NotEmpty(t, result.PlaceOfBirth)
+	assert.NotEmpty(t, result.Filmography)
+}
+
+func TestGetPersonLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/person/1", r.URL.Path)
+		assert.Equal(t, "combined_credits", r.URL.Query().Get("append_to_response"))
+		_, _ = w.Write([]byte(`{
+			"id": 1,
+			"name": "Yui Aragaki",
+			"also_known_as": ["新垣結衣"],
+			"birthday": "1988-06-11",
+			"place_of_birth": "Naha, Okinawa, Japan",
+			"known_for_department": "Acting",
+			"combined_credits": {
+				"cast": [
+					{"id": 10, "media_type": "movie", "title": "Old", "release_date": "2007-01-01"},
+					{"id": 11, "media_type": "tv", "name": "Upcoming"},
+					{"id": 12, "media_type": "tv", "name": "New", "original_name": "新", "first_air_date": "2016-10-11"}
+				],
+				"crew": [
+					{"id": 13, "media_type": "tv", "name": "Produced", "original_name": "制作", "first_air_date": "2012-04-01", "job": "Producer"}
+				]
+			}
+		}`))
+	}))
+	tmdb := NewTMDB("key", "en-US", client, nil)
+
+	result, err := tmdb.getPerson(t.Context(), TMDBGetPersonInput{ID: 1})
+	require.NoError(t, err)
+	// Kept from the find_by_imdb_id output, TMDB has no original name for people.
+	assert.Equal(t, "Yui Aragaki", result.OriginalName)
+	assert.Equal(t, []string{"新垣結衣"}, result.AlsoKnownAs)
+	assert.Equal(t, "1988-06-11", result.Birthday)
+	require.Len(t, result.Filmography, 4)
+	assert.Equal(t, TMDBCredit{ID: 12, MediaType: "tv", Title: "New", OriginalTitle: "新", Date: "2016-10-11"},
+		result.Filmography[0])
+	assert.Equal(t, TMDBCredit{ID: 13, MediaType: "tv", Title: "Produced", OriginalTitle: "制作", Job: "Producer", Date: "2012-04-01"},
+		result.Filmography[1])
+	assert.Equal(t, "Old", result.Filmography[2].Title)
+	assert.Equal(t, "Upcoming", result.Filmography[3].Title)
+}
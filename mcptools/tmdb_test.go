@@ -1,8 +1,14 @@
 package mcptools
 
 import (
+	"context"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +25,7 @@ func tmdbAPIKeyFromEnv(t *testing.T) string {
 
 func TestSearchMovies(t *testing.T) {
 	key := tmdbAPIKeyFromEnv(t)
-	tmdb := NewTMDB(key, "en-US")
+	tmdb := NewTMDB(key, "en-US", nil, nil)
 
 	tests := []struct {
 		name  string
@@ -42,7 +48,7 @@ func TestSearchMovies(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := tmdb.searchMovies(tt.input)
+			result, err := tmdb.searchMovies(t.Context(), tt.input)
 			require.NoError(t, err)
 			require.NotEmpty(t, result.Results)
 		})
@@ -51,17 +57,17 @@ func TestSearchMovies(t *testing.T) {
 
 func TestSearchMoviesNotExists(t *testing.T) {
 	key := tmdbAPIKeyFromEnv(t)
-	tmdb := NewTMDB(key, "en-US")
+	tmdb := NewTMDB(key, "en-US", nil, nil)
 	// Year is wrong
-	result, err := tmdb.searchMovies(TMDBSearchMovieInput{Name: "The Matrix", Year: 1990})
+	result, err := tmdb.searchMovies(t.Context(), TMDBSearchMovieInput{Name: "The Matrix", Year: 1990})
 	require.NoError(t, err)
 	require.Empty(t, result.Results)
 }
 
 func TestSearchTVShows(t *testing.T) {
 	key := tmdbAPIKeyFromEnv(t)
-	tmdb := NewTMDB(key, "en-US")
-	result, err := tmdb.searchTVShows(TMDBSearchTVShowInput{Name: "Breaking Bad"})
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	result, err := tmdb.searchTVShows(t.Context(), TMDBSearchTVShowInput{Name: "Breaking Bad"})
 	require.NoError(t, err)
 	require.NotEmpty(t, result.Results)
 	assert.Equal(t, "Breaking Bad", result.Results[0].Name)
@@ -70,7 +76,7 @@ func TestSearchTVShows(t *testing.T) {
 
 func TestFindByIMDB(t *testing.T) {
 	key := tmdbAPIKeyFromEnv(t)
-	tmdb := NewTMDB(key, "en-US")
+	tmdb := NewTMDB(key, "en-US", nil, nil)
 
 	tests := []struct {
 		name         string
@@ -129,7 +135,7 @@ func TestFindByIMDB(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := tmdb.findByIMDB(TMDBFindByIMDBInput{IMDBID: tt.imdbID})
+			result, err := tmdb.findByIMDB(t.Context(), TMDBFindByIMDBInput{IMDBID: tt.imdbID})
 			require.NoError(t, err)
 
 			switch tt.expectType {
@@ -150,3 +156,93 @@ func TestFindByIMDB(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchMoviesLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/3/search/movie":
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":1,"title":"First"},{"id":2,"title":"Second"},{"id":3,"title":"Third"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/3/movie/"):
+			id := strings.Split(r.URL.Path, "/")[3]
+			// Earlier results answer slower, the output must keep search order.
+			n, _ := strconv.Atoi(id)
+			time.Sleep(time.Duration(4-n) * 10 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"id":` + id + `,"imdb_id":"tt` + id + `","title":"Detail ` + id + `",
+				"credits":{"cast":[{"name":"Actor ` + id + `","known_for_department":"Acting"}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	tmdb := NewTMDB("key", "en-US", client, nil)
+
+	result, err := tmdb.searchMovies(t.Context(), TMDBSearchMovieInput{Name: "anything"})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 3)
+	for i := range 3 {
+		id := strconv.Itoa(i + 1)
+		assert.Equal(t, int64(i+1), result.Results[i].ID)
+		assert.Equal(t, "tt"+id, result.Results[i].IMDBID)
+		assert.Equal(t, "Detail "+id, result.Results[i].Title)
+		require.Len(t, result.Results[i].Actors, 1)
+		assert.Equal(t, "Actor "+strconv.Itoa(i+1), result.Results[i].Actors[0].Name)
+	}
+}
+
+func TestSearchMoviesCancelled(t *testing.T) {
+	var detailRequests atomic.Int32
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/3/search/movie" {
+			_, _ = w.Write([]byte(`{"results":[{"id":1},{"id":2},{"id":3}]}`))
+			return
+		}
+		detailRequests.Add(1)
+		_, _ = w.Write([]byte(`{"cast":[]}`))
+	}))
+	tmdb := NewTMDB("key", "en-US", client, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := tmdb.searchMovies(ctx, TMDBSearchMovieInput{Name: "anything"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, detailRequests.Load())
+}
+
+func TestGetMovie(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	// The Matrix
+	result, err := tmdb.getMovie(t.Context(), TMDBGetMovieInput{ID: 603})
+	require.NoError(t, err)
+	assert.Equal(t, int64(603), result.ID)
+	assert.Equal(t, "tt0133093", result.IMDBID)
+	assert.NotEmpty(t, result.Actors)
+}
+
+func TestGetTVShow(t *testing.T) {
+	key := tmdbAPIKeyFromEnv(t)
+	tmdb := NewTMDB(key, "en-US", nil, nil)
+	// Breaking Bad
+	result, err := tmdb.getTVShow(t.Context(), TMDBGetTVShowInput{ID: 1396})
+	require.NoError(t, err)
+	assert.Equal(t, "tt0903747", result.IMDBID)
+	assert.Equal(t, int64(81189), result.TVDBID)
+}
+
+func TestGetTVShowLocal(t *testing.T) {
+	client := localAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/tv/7", r.URL.Path)
+		assert.Equal(t, "credits,external_ids", r.URL.Query().Get("append_to_response"))
+		assert.Equal(t, "en-US", r.URL.Query().Get("language"))
+		_, _ = w.Write([]byte(`{
+			"id": 7,
+			"name": "Show",
+			"external_ids": {"imdb_id": "tt7", "tvdb_id": 70}
+		}`))
+	}))
+	tmdb := NewTMDB("key", "zh-CN", client, nil)
+
+	result, err := tmdb.getTVShow(t.Context(), TMDBGetTVShowInput{ID: 7, Language: "en-US"})
+	require.NoError(t, err)
+	assert.Equal(t, TMDBTVShowItem{ID: 7, IMDBID: "tt7", TVDBID: 70, Name: "Show"}, result)
+}
@@ -0,0 +1,144 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	webSearchDefaultMaxResults = 10
+	webSearchMaxResultsLimit   = 30
+)
+
+var (
+	webSearchSafeSearch = []string{"strict", "moderate", "off"}
+	webSearchTimeRange  = []string{"day", "week", "month", "year"}
+)
+
+// SearchBackend is a search engine behind the web_search tool. Search gets a validated input
+// with MaxResults set, and returns an error when the engine can't answer, e.g. rate limited.
+// No results also moves on to the next backend, as a degraded engine often answers empty.
+type SearchBackend interface {
+	Name() string
+	Search(ctx context.Context, input WebSearchInput) ([]WebSearchResult, error)
+}
+
+// WebSearch serves web_search from a list of backends, trying the next one when a backend fails
+// or finds nothing.
+type WebSearch struct {
+	backends []SearchBackend
+}
+
+func NewWebSearch(backends ...SearchBackend) *WebSearch {
+	return &WebSearch{
+		backends: backends,
+	}
+}
+
+func (s *WebSearch) AddTools(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "web_search",
+		Description: "Performs a web search and returns the title, url, snippet and domain of each result.",
+	}, s.webSearchTool)
+}
+
+type WebSearchInput struct {
+	Query      string `json:"query"`
+	Region     string `json:"region,omitempty" jsonschema:"(optional) region code, e.g. 'us-en', 'cn-zh' or 'jp-jp', default is no region"`
+	SafeSearch string `json:"safe_search,omitempty" jsonschema:"(optional) one of 'strict', 'moderate' or 'off', default is moderate"`
+	TimeRange  string `json:"time_range,omitempty" jsonschema:"(optional) only return results from the last 'day', 'week', 'month' or 'year'"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"(optional) the maximum number of results, default is 10, at most 30"`
+}
+
+type WebSearchResult struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet,omitempty"`
+	Domain  string `json:"domain"`
+}
+
+type WebSearchOutput struct {
+	Backend string            `json:"backend" jsonschema:"the search engine that answered"`
+	Results []WebSearchResult `json:"results"`
+}
+
+// newWebSearchResult builds a result from an absolute url, reporting false for anything else.
+func newWebSearchResult(title, rawURL, snippet string) (WebSearchResult, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return WebSearchResult{}, false
+	}
+	return WebSearchResult{
+		Title:   strings.TrimSpace(title),
+		URL:     u.String(),
+		Snippet: strings.TrimSpace(snippet),
+		Domain:  u.Hostname(),
+	}, true
+}
+
+// regionParts splits a region code like "us-en" into country and language. "wt-wt" is no region.
+func regionParts(region string) (country, language string) {
+	region = strings.ToLower(region)
+	if region == "" || region == "wt-wt" {
+		return "", ""
+	}
+	country, language, _ = strings.Cut(region, "-")
+	return country, language
+}
+
+func (s *WebSearch) search(ctx context.Context, input WebSearchInput) (WebSearchOutput, error) {
+	if input.SafeSearch != "" && !slices.Contains(webSearchSafeSearch, input.SafeSearch) {
+		return WebSearchOutput{}, fmt.Errorf("invalid safe_search %q, must be one of strict, moderate or off", input.SafeSearch)
+	}
+	if input.TimeRange != "" && !slices.Contains(webSearchTimeRange, input.TimeRange) {
+		return WebSearchOutput{}, fmt.Errorf("invalid time_range %q, must be one of day, week, month or year", input.TimeRange)
+	}
+	if input.MaxResults <= 0 {
+		input.MaxResults = webSearchDefaultMaxResults
+	}
+	input.MaxResults = min(input.MaxResults, webSearchMaxResultsLimit)
+
+	var errs []error
+	var empty *WebSearchOutput
+	for _, backend := range s.backends {
+		results, err := backend.Search(ctx, input)
+		if err == nil && len(results) > 0 {
+			return WebSearchOutput{Backend: backend.Name(), Results: results}, nil
+		}
+		if err == nil {
+			if empty == nil {
+				empty = &WebSearchOutput{Backend: backend.Name(), Results: []WebSearchResult{}}
+			}
+			log.Printf("No results from %s, trying next backend", backend.Name())
+			continue
+		}
+		// No point trying the next backend once the call is cancelled.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return WebSearchOutput{}, ctxErr
+		}
+		log.Printf("Error searching %s, trying next backend: %v", backend.Name(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", backend.Name(), err))
+	}
+	// Only empty once every backend had its chance.
+	if empty != nil {
+		return *empty, nil
+	}
+	if len(errs) == 0 {
+		return WebSearchOutput{}, errors.New("no web search backend configured")
+	}
+	return WebSearchOutput{}, fmt.Errorf("all web search backends failed: %w", errors.Join(errs...))
+}
+
+func (s *WebSearch) webSearchTool(
+	ctx context.Context, req *mcp.CallToolRequest, input WebSearchInput) (
+	*mcp.CallToolResult, WebSearchOutput, error) {
+	result, err := s.search(ctx, input)
+	return nil, result, err
+}
@@ -0,0 +1,113 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSearchBackend struct {
+	name    string
+	err     error
+	results []WebSearchResult
+	inputs  []WebSearchInput
+}
+
+func (b *fakeSearchBackend) Name() string {
+	return b.name
+}
+
+func (b *fakeSearchBackend) Search(ctx context.Context, input WebSearchInput) ([]WebSearchResult, error) {
+	b.inputs = append(b.inputs, input)
+	return b.results, b.err
+}
+
+func TestWebSearchFallback(t *testing.T) {
+	failing := &fakeSearchBackend{name: "first", err: errors.New("rate limited")}
+	working := &fakeSearchBackend{name: "second", results: []WebSearchResult{{Title: "Go", URL: "https://go.dev/", Domain: "go.dev"}}}
+	unused := &fakeSearchBackend{name: "third"}
+	s := NewWebSearch(failing, working, unused)
+
+	result, err := s.search(t.Context(), WebSearchInput{Query: "golang"})
+	require.NoError(t, err)
+	assert.Equal(t, "second", result.Backend)
+	assert.Equal(t, working.results, result.Results)
+	assert.Len(t, failing.inputs, 1)
+	assert.Empty(t, unused.inputs)
+	// Backends get the resolved max results.
+	assert.Equal(t, webSearchDefaultMaxResults, working.inputs[0].MaxResults)
+}
+
+func TestWebSearchAllBackendsFail(t *testing.T) {
+	s := NewWebSearch(
+		&fakeSearchBackend{name: "first", err: errors.New("rate limited")},
+		&fakeSearchBackend{name: "second", err: errors.New("captcha")},
+	)
+
+	_, err := s.search(t.Context(), WebSearchInput{Query: "golang"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first: rate limited")
+	assert.Contains(t, err.Error(), "second: captcha")
+}
+
+func TestWebSearchEmptyFallback(t *testing.T) {
+	empty := &fakeSearchBackend{name: "first"}
+	working := &fakeSearchBackend{name: "second", results: []WebSearchResult{{Title: "Go", URL: "https://go.dev/", Domain: "go.dev"}}}
+	s := NewWebSearch(empty, working)
+
+	result, err := s.search(t.Context(), WebSearchInput{Query: "golang"})
+	require.NoError(t, err)
+	assert.Equal(t, "second", result.Backend)
+	assert.Equal(t, working.results, result.Results)
+
+	// Empty is only returned once every backend answered empty or failed.
+	s = NewWebSearch(
+		&fakeSearchBackend{name: "first", err: errors.New("rate limited")},
+		&fakeSearchBackend{name: "second"},
+		&fakeSearchBackend{name: "third", results: []WebSearchResult{}},
+	)
+	result, err = s.search(t.Context(), WebSearchInput{Query: "golang"})
+	require.NoError(t, err)
+	assert.Equal(t, WebSearchOutput{Backend: "second", Results: []WebSearchResult{}}, result)
+}
+
+func TestWebSearchInvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   WebSearchInput
+		wantErr string
+	}{
+		{
+			name:    "safe search",
+			input:   WebSearchInput{Query: "golang", SafeSearch: "none"},
+			wantErr: "invalid safe_search",
+		},
+		{
+			name:    "time range",
+			input:   WebSearchInput{Query: "golang", TimeRange: "hour"},
+			wantErr: "invalid time_range",
+		},
+	}
+
+	backend := &fakeSearchBackend{name: "fake"}
+	s := NewWebSearch(backend)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.search(t.Context(), tt.input)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+	assert.Empty(t, backend.inputs)
+}
+
+func TestWebSearchMaxResultsLimit(t *testing.T) {
+	backend := &fakeSearchBackend{name: "fake"}
+	s := NewWebSearch(backend)
+
+	_, err := s.search(t.Context(), WebSearchInput{Query: "golang", MaxResults: 100})
+	require.NoError(t, err)
+	assert.Equal(t, webSearchMaxResultsLimit, backend.inputs[0].MaxResults)
+}
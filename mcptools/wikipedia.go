@@ -2,19 +2,37 @@ package mcptools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	gowiki "github.com/trietmn/go-wiki"
+)
+
+const (
+	wikipediaAPIURL           = "https://%s.wikipedia.org/w/api.php"
+	wikipediaSearchLimit      = 3
+	wikipediaSummarySentences = 5
 )
 
 type Wikipedia struct {
+	language string
+	client   *http.Client
+	// apiURL is formatted with the language.
+	apiURL string
 }
 
-func NewWikipedia(language string) *Wikipedia {
-	gowiki.SetUserAgent(userAgent)
-	gowiki.SetLanguage(language)
-	return &Wikipedia{}
+func NewWikipedia(language string, client *http.Client) *Wikipedia {
+	return &Wikipedia{
+		language: language,
+		client:   httpClientOrDefault(client),
+		apiURL:   wikipediaAPIURL,
+	}
 }
 
 func (w *Wikipedia) AddTools(server *mcp.Server) {
@@ -28,8 +46,118 @@ func (w *Wikipedia) AddTools(server *mcp.Server) {
 	}, w.wikipediaPageTool)
 }
 
+type wikipediaQueryResponse struct {
+	Error struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+	Query struct {
+		Search []struct {
+			Title string `json:"title"`
+		} `json:"search"`
+		Pages []struct {
+			Title   string `json:"title"`
+			Missing bool   `json:"missing"`
+			Extract string `json:"extract"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+var errWikipediaPageMissing = errors.New("wikipedia page not found")
+
+// wikipediaLanguagePattern matches Wikipedia subdomains like "en", "zh-yue" or "simple". The
+// language ends up in the API host name, so anything else is rejected.
+var wikipediaLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]+)*$|^simple$`)
+
+// languageFor returns the language requested by a tool call, or the configured one.
+func (w *Wikipedia) languageFor(language string) (string, error) {
+	if language == "" {
+		return w.language, nil
+	}
+	language = strings.ToLower(language)
+	if !wikipediaLanguagePattern.MatchString(language) {
+		return "", fmt.Errorf("invalid wikipedia language %q", language)
+	}
+	return language, nil
+}
+
+// query calls the MediaWiki action API of the given language.
+func (w *Wikipedia) query(ctx context.Context, language string, params url.Values) (wikipediaQueryResponse, error) {
+	u, err := url.Parse(fmt.Sprintf(w.apiURL, language))
+	if err != nil {
+		return wikipediaQueryResponse{}, err
+	}
+	params.Set("action", "query")
+	params.Set("format", "json")
+	params.Set("formatversion", "2")
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return wikipediaQueryResponse{}, err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return wikipediaQueryResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return wikipediaQueryResponse{}, fmt.Errorf("wikipedia api responded with status code: %d", resp.StatusCode)
+	}
+
+	res := wikipediaQueryResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return wikipediaQueryResponse{}, err
+	}
+	if res.Error.Code != "" {
+		return wikipediaQueryResponse{}, fmt.Errorf("wikipedia api error %s: %s", res.Error.Code, res.Error.Info)
+	}
+	return res, nil
+}
+
+func (w *Wikipedia) search(ctx context.Context, language, query string, limit int) ([]string, error) {
+	res, err := w.query(ctx, language, url.Values{
+		"list":     {"search"},
+		"srsearch": {query},
+		"srlimit":  {fmt.Sprint(limit)},
+		"srprop":   {""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var titles []string
+	for _, item := range res.Query.Search {
+		titles = append(titles, item.Title)
+	}
+	return titles, nil
+}
+
+// extract returns the plain text of a page, following redirects. sentences <= 0 returns the whole page.
+func (w *Wikipedia) extract(ctx context.Context, language, title string, sentences int) (string, error) {
+	params := url.Values{
+		"prop":        {"extracts"},
+		"explaintext": {"1"},
+		"redirects":   {"1"},
+		"titles":      {title},
+	}
+	if sentences > 0 {
+		params.Set("exsentences", fmt.Sprint(sentences))
+	}
+	res, err := w.query(ctx, language, params)
+	if err != nil {
+		return "", err
+	}
+	if len(res.Query.Pages) == 0 || res.Query.Pages[0].Missing {
+		return "", errWikipediaPageMissing
+	}
+	return res.Query.Pages[0].Extract, nil
+}
+
 type WikipediaSearchInput struct {
-	Query string `json:"query"`
+	Query    string `json:"query"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the Wikipedia language code, e.g. 'en' or 'zh', defaults to the configured language"`
 }
 
 type WikipediaSearchItem struct {
@@ -41,8 +169,13 @@ type WikipediaSearchOutput struct {
 	Results []WikipediaSearchItem `json:"results"`
 }
 
-func (w *Wikipedia) searchWikipedia(input WikipediaSearchInput) (WikipediaSearchOutput, error) {
-	searchResults, _, err := gowiki.Search(input.Query, 3, false)
+func (w *Wikipedia) searchWikipedia(ctx context.Context, input WikipediaSearchInput) (WikipediaSearchOutput, error) {
+	language, err := w.languageFor(input.Language)
+	if err != nil {
+		return WikipediaSearchOutput{}, err
+	}
+
+	searchResults, err := w.search(ctx, language, input.Query, wikipediaSearchLimit)
 	if err != nil {
 		return WikipediaSearchOutput{}, err
 	}
@@ -53,7 +186,7 @@ func (w *Wikipedia) searchWikipedia(input WikipediaSearchInput) (WikipediaSearch
 			Title: result,
 		}
 
-		summary, err := gowiki.Summary(result, 5, -1, false, true)
+		summary, err := w.extract(ctx, language, result, wikipediaSummarySentences)
 		if err != nil {
 			log.Printf("Error getting page %v summary: %v", result, err)
 		} else {
@@ -68,25 +201,37 @@ func (w *Wikipedia) searchWikipedia(input WikipediaSearchInput) (WikipediaSearch
 func (w *Wikipedia) searchWikipediaTool(
 	ctx context.Context, req *mcp.CallToolRequest, input WikipediaSearchInput) (
 	*mcp.CallToolResult, WikipediaSearchOutput, error) {
-	result, err := w.searchWikipedia(input)
+	result, err := w.searchWikipedia(ctx, input)
 	return nil, result, err
 }
 
 type WikipediaPageInput struct {
-	Title string `json:"title"`
+	Title    string `json:"title"`
+	Language string `json:"language,omitempty" jsonschema:"(optional) the Wikipedia language code, e.g. 'en' or 'zh', defaults to the configured language"`
 }
 
 type WikipediaPageOutput struct {
 	Content string `json:"content"`
 }
 
-func (w *Wikipedia) wikipediaPage(input WikipediaPageInput) (WikipediaPageOutput, error) {
-	page, err := gowiki.GetPage(input.Title, -1, true, true)
+func (w *Wikipedia) wikipediaPage(ctx context.Context, input WikipediaPageInput) (WikipediaPageOutput, error) {
+	language, err := w.languageFor(input.Language)
 	if err != nil {
 		return WikipediaPageOutput{}, err
 	}
 
-	content, err := page.GetContent()
+	content, err := w.extract(ctx, language, input.Title, 0)
+	if errors.Is(err, errWikipediaPageMissing) {
+		// Not an exact title, use the best search hit instead.
+		titles, searchErr := w.search(ctx, language, input.Title, 1)
+		if searchErr != nil {
+			return WikipediaPageOutput{}, searchErr
+		}
+		if len(titles) == 0 {
+			return WikipediaPageOutput{}, err
+		}
+		content, err = w.extract(ctx, language, titles[0], 0)
+	}
 	if err != nil {
 		return WikipediaPageOutput{}, err
 	}
@@ -97,6 +242,6 @@ func (w *Wikipedia) wikipediaPage(input WikipediaPageInput) (WikipediaPageOutput
 func (w *Wikipedia) wikipediaPageTool(
 	ctx context.Context, req *mcp.CallToolRequest, input WikipediaPageInput) (
 	*mcp.CallToolResult, WikipediaPageOutput, error) {
-	result, err := w.wikipediaPage(input)
+	result, err := w.wikipediaPage(ctx, input)
 	return nil, result, err
 }
@@ -1,15 +1,18 @@
 package mcptools
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWikipedia_searchWikipedia(t *testing.T) {
-	w := NewWikipedia("en")
+	w := NewWikipedia("en", nil)
 	input := WikipediaSearchInput{Query: "Go programming language"}
-	output, err := w.searchWikipedia(input)
+	output, err := w.searchWikipedia(t.Context(), input)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, output.Results, "Expected search results to not be empty")
@@ -23,10 +26,74 @@ func TestWikipedia_searchWikipedia(t *testing.T) {
 }
 
 func TestWikipedia_wikipediaPage(t *testing.T) {
-	w := NewWikipedia("en")
+	w := NewWikipedia("en", nil)
 	input := WikipediaPageInput{Title: "Go (programming language)"}
-	output, err := w.wikipediaPage(input)
+	output, err := w.wikipediaPage(t.Context(), input)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, output.Content, "Expected page content to not be empty")
 }
+
+func TestWikipedia_localAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/en/w/api.php", r.URL.Path)
+		switch {
+		case q.Get("list") == "search":
+			_, _ = w.Write([]byte(`{"query":{"search":[{"title":"Go (programming language)"}]}}`))
+		case q.Get("titles") == "Go (programming language)":
+			_, _ = w.Write([]byte(`{"query":{"pages":[{"title":"Go (programming language)","extract":"Go is a language."}]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"query":{"pages":[{"title":"` + q.Get("titles") + `","missing":true}]}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	w := NewWikipedia("en", nil)
+	w.apiURL = server.URL + "/%s/w/api.php"
+
+	search, err := w.searchWikipedia(t.Context(), WikipediaSearchInput{Query: "golang"})
+	require.NoError(t, err)
+	require.Len(t, search.Results, 1)
+	assert.Equal(t, "Go is a language.", search.Results[0].Summary)
+
+	// Inexact title falls back to the best search hit.
+	page, err := w.wikipediaPage(t.Context(), WikipediaPageInput{Title: "golang"})
+	require.NoError(t, err)
+	assert.Equal(t, "Go is a language.", page.Content)
+}
+
+func TestWikipedia_languageOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ja/w/api.php", r.URL.Path)
+		_, _ = w.Write([]byte(`{"query":{"pages":[{"title":"東京","extract":"東京は日本の首都。"}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	w := NewWikipedia("zh", nil)
+	w.apiURL = server.URL + "/%s/w/api.php"
+
+	page, err := w.wikipediaPage(t.Context(), WikipediaPageInput{Title: "東京", Language: "JA"})
+	require.NoError(t, err)
+	assert.Equal(t, "東京は日本の首都。", page.Content)
+}
+
+func TestWikipedia_invalidLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+	}{
+		{name: "host injection", language: "en.example.com/"},
+		{name: "path", language: "en/../"},
+		{name: "too long", language: "english"},
+	}
+
+	w := NewWikipedia("en", nil)
+	w.apiURL = "http://127.0.0.1:0/%s"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := w.wikipediaPage(t.Context(), WikipediaPageInput{Title: "Go", Language: tt.language})
+			require.ErrorContains(t, err, "invalid wikipedia language")
+		})
+	}
+}